@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// blockByHash returns the block of blockchain with the given hash
+func blockByHash(blockchain []Block, hash string) (Block, bool) {
+	for _, block := range blockchain {
+		if block.Hash == hash {
+			return block, true
+		}
+	}
+	return Block{}, false
+}
+
+// getAncestor follows PreviousHash n times from the block with the given hash; n = 0 returns the block itself
+func getAncestor(blockchain []Block, hash string, n int) (Block, error) {
+	if n < 0 {
+		return Block{}, fmt.Errorf("n tidak boleh negatif")
+	}
+	block, ok := blockByHash(blockchain, hash)
+	if !ok {
+		return Block{}, fmt.Errorf("blok %s tidak ditemukan", hash)
+	}
+	if n > block.Index {
+		return Block{}, fmt.Errorf("blok %d hanya memiliki %d leluhur", block.Index, block.Index)
+	}
+	for ; n > 0; n-- {
+		if block, ok = blockByHash(blockchain, block.PreviousHash); !ok {
+			return Block{}, fmt.Errorf("parent %s tidak ditemukan", block.PreviousHash)
+		}
+	}
+	return block, nil
+}
+
+// isAncestor reports whether block a lies on the path from block b back to genesis; a block
+// counts as its own ancestor, so isAncestor(x, tip) answers "is x part of the main chain?"
+func isAncestor(blockchain []Block, a, b string) (bool, error) {
+	ancestor, ok := blockByHash(blockchain, a)
+	if !ok {
+		return false, fmt.Errorf("blok %s tidak ditemukan", a)
+	}
+	descendant, ok := blockByHash(blockchain, b)
+	if !ok {
+		return false, fmt.Errorf("blok %s tidak ditemukan", b)
+	}
+	if ancestor.Index > descendant.Index {
+		return false, nil
+	}
+	block, err := getAncestor(blockchain, descendant.Hash, descendant.Index-ancestor.Index)
+	if err != nil {
+		return false, err
+	}
+	return block.Hash == ancestor.Hash, nil
+}
+
+// BlockByHash returns the block with the given hash
+func (c *Chain) BlockByHash(hash string) (Block, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return blockByHash(c.blocks, hash)
+}
+
+// GetAncestor returns the ancestor n blocks below the block with the given hash
+func (c *Chain) GetAncestor(hash string, n int) (Block, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return getAncestor(c.blocks, hash, n)
+}
+
+// IsAncestor reports whether block a is an ancestor of (or equal to) block b
+func (c *Chain) IsAncestor(a, b string) (bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return isAncestor(c.blocks, a, b)
+}
+
+// handleGetAncestor implements GET /ancestors/{hash}?n=
+func (s *apiServer) handleGetAncestor(w http.ResponseWriter, r *http.Request) {
+	n, err := queryInt(r.URL.Query(), "n", 1)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "n harus berupa angka")
+		return
+	}
+	block, err := s.chain.GetAncestor(r.PathValue("hash"), n)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, block)
+}
+
+// handleIsAncestor implements GET /is-ancestor?a=&b=; without b the tip is used
+func (s *apiServer) handleIsAncestor(w http.ResponseWriter, r *http.Request) {
+	a, b := r.URL.Query().Get("a"), r.URL.Query().Get("b")
+	if b == "" {
+		b = s.chain.Tip().Hash
+	}
+	ancestor, err := s.chain.IsAncestor(a, b)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"a": a, "b": b, "is_ancestor": ancestor})
+}
+
+// runAncestorCommand implements "ancestor <hash|index> [n]"
+func runAncestorCommand(store BlockStore, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return fmt.Errorf("penggunaan: ancestor <hash|index> [n]")
+	}
+	n := 1
+	if len(args) == 2 {
+		var err error
+		if n, err = strconv.Atoi(args[1]); err != nil {
+			return fmt.Errorf("n harus berupa angka")
+		}
+	}
+
+	blockchain, err := loadAncestryChain(store)
+	if err != nil {
+		return err
+	}
+	start, err := findParent(blockchain, args[0])
+	if err != nil {
+		return err
+	}
+	block, err := getAncestor(blockchain, start.Hash, n)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Leluhur ke-%d dari blok %d:\n", n, start.Index)
+	fmt.Printf("%sBlock %d%s  %s\n", BoldCyan, block.Index, Reset, block.Hash)
+	return nil
+}
+
+// runIsAncestorCommand implements "isancestor <a> [b]"; without b the tip is used
+func runIsAncestorCommand(store BlockStore, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return fmt.Errorf("penggunaan: isancestor <hash|index> [hash|index]")
+	}
+	blockchain, err := loadAncestryChain(store)
+	if err != nil {
+		return err
+	}
+	a, err := findParent(blockchain, args[0])
+	if err != nil {
+		return err
+	}
+	b := blockchain[len(blockchain)-1]
+	if len(args) == 2 {
+		if b, err = findParent(blockchain, args[1]); err != nil {
+			return err
+		}
+	}
+
+	ancestor, err := isAncestor(blockchain, a.Hash, b.Hash)
+	if err != nil {
+		return err
+	}
+	if ancestor {
+		fmt.Printf(Green+"Blok %d adalah leluhur blok %d.\n"+Reset, a.Index, b.Index)
+	} else {
+		fmt.Printf(Yellow+"Blok %d bukan leluhur blok %d.\n"+Reset, a.Index, b.Index)
+	}
+	return nil
+}
+
+// loadAncestryChain loads the local chain for the ancestry commands
+func loadAncestryChain(store BlockStore) ([]Block, error) {
+	_, blockchain, err := loadChain(store)
+	if err != nil {
+		return nil, err
+	}
+	if len(blockchain) == 0 {
+		return nil, fmt.Errorf("blockchain lokal kosong")
+	}
+	return blockchain, nil
+}
@@ -0,0 +1,398 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// apiServer exposes the chain over a small JSON REST API
+type apiServer struct {
+	chain     *Chain
+	queue     *miningQueue
+	keys      *apiKeyStore // nil berarti tanpa autentikasi
+	identity  *nodeIdentity
+	webhooks  *webhookDispatcher
+	schedules *blockScheduler
+	readOnly  bool // Hanya endpoint baca yang didaftarkan (mode explorer publik)
+	pprof     bool // Daftarkan endpoint net/http/pprof untuk admin
+
+	limiter *rateLimiter // nil berarti tanpa rate limit
+}
+
+// newAPIServer creates the API server for chain; keys may be nil to disable authentication
+func newAPIServer(chain *Chain, queue *miningQueue, keys *apiKeyStore, identity *nodeIdentity) *apiServer {
+	return &apiServer{chain: chain, queue: queue, keys: keys, identity: identity}
+}
+
+// apiRoute is one REST endpoint; the route table drives both the mux and the OpenAPI document
+type apiRoute struct {
+	Method     string
+	Path       string
+	Permission permission
+	Public     bool   // Tanpa autentikasi sama sekali
+	Summary    string // Deskripsi singkat untuk dokumentasi OpenAPI
+	Body       string // Contoh body JSON request, kosong jika tanpa body
+	Handler    http.HandlerFunc
+}
+
+// routeTable lists every endpoint with the permission it requires; in read-only mode only
+// endpoints needing at most read permission are included
+func (s *apiServer) routeTable() []apiRoute {
+	routes := []apiRoute{
+		{Method: "GET", Path: "/blocks", Permission: permRead, Summary: "Daftar blok per halaman; query cursor, limit, from, to, q; ETag dan If-None-Match didukung", Handler: s.handleListBlocks},
+		{Method: "GET", Path: "/blocks/{index}", Permission: permRead, Summary: "Blok dengan index tertentu", Handler: s.handleGetBlock},
+		{Method: "GET", Path: "/blocks/{index}/payload", Permission: permRead, Summary: "Payload terstruktur blok (kv, json, atau digest); ?key= mengambil satu record kv", Handler: s.handleGetPayload},
+		{Method: "GET", Path: "/blocks/{index}/raw", Permission: permRead, Summary: "Blok dalam bentuk JSON, raw hex yang di-hash, dan rincian field dengan offset byte", Handler: s.handleGetBlockDetail},
+		{Method: "GET", Path: "/ancestors/{hash}", Permission: permRead, Summary: "Leluhur ke-n (query n, default 1) dari blok dengan hash tertentu", Handler: s.handleGetAncestor},
+		{Method: "GET", Path: "/is-ancestor", Permission: permRead, Summary: "Apakah blok a leluhur blok b; tanpa b dibandingkan dengan tip (bagian dari chain utama?)", Handler: s.handleIsAncestor},
+		{Method: "GET", Path: "/validate", Permission: permRead, Summary: "Validasi seluruh blockchain", Handler: s.handleValidate},
+		{Method: "GET", Path: "/me", Permission: permRead, Summary: "Pengguna dan permission API key yang dipakai", Handler: s.handleWhoAmI},
+		// Tanpa autentikasi: dipakai untuk handshake identitas
+		{Method: "GET", Path: "/node", Public: true, Summary: "Identitas node; dengan ?challenge= node menandatangani challenge", Handler: s.handleNodeIdentity},
+		{Method: "POST", Path: "/blocks", Permission: permMine, Summary: "Mining blok baru dan tunggu hasilnya; callback_url membutuhkan API key dengan permission admin", Body: `{"data": "...", "callback_url": ""}`, Handler: s.handleMineBlock},
+		{Method: "POST", Path: "/blocks/submit", Permission: permMine, Summary: "Kirim blok yang di-mining di luar node; diterima jika lolos semua aturan validasi", Body: `{"index": 1, "timestamp": "...", "data": "...", "nonce": 0, "hash": "...", "previous_hash": "...", "difficulty": 4}`, Handler: s.handleSubmitBlock},
+		{Method: "GET", Path: "/jobs", Permission: permRead, Summary: "Daftar pekerjaan mining per halaman; query cursor dan limit. Hanya 1000 pekerjaan selesai terakhir yang disimpan", Handler: s.handleListJobs},
+		{Method: "GET", Path: "/jobs/{id}", Permission: permRead, Summary: "Status pekerjaan mining; ?wait=true menunggu sampai selesai", Handler: s.handleGetJob},
+		{Method: "POST", Path: "/jobs", Permission: permMine, Summary: "Masukkan permintaan blok ke antrian mining; callback_url membutuhkan API key dengan permission admin", Body: `{"data": "...", "callback_url": ""}`, Handler: s.handleSubmitJob},
+		{Method: "DELETE", Path: "/jobs/{id}", Permission: permMine, Summary: "Batalkan pekerjaan mining", Handler: s.handleCancelJob},
+		{Method: "GET", Path: "/schedules", Permission: permRead, Summary: "Daftar jadwal permintaan blok berulang", Handler: s.handleListSchedules},
+		{Method: "POST", Path: "/schedules", Permission: permMine, Summary: "Kirim permintaan blok yang sama setiap N blok atau setiap interval sampai dibatalkan", Body: `{"data": "...", "every_blocks": 5, "interval": "", "count": 0}`, Handler: s.handleAddSchedule},
+		{Method: "DELETE", Path: "/schedules/{id}", Permission: permMine, Summary: "Batalkan jadwal", Handler: s.handleCancelSchedule},
+		{Method: "GET", Path: "/difficulty", Permission: permRead, Summary: "Tingkat kesulitan blok berikutnya", Handler: s.handleGetDifficulty},
+		{Method: "PUT", Path: "/difficulty", Permission: permAdmin, Summary: "Ubah tingkat kesulitan", Body: `{"difficulty": 4}`, Handler: s.handleSetDifficulty},
+		{Method: "GET", Path: "/evidence", Permission: permRead, Summary: "Evidence double signing yang menunggu dimasukkan ke blok", Handler: s.handleListEvidence},
+		{Method: "POST", Path: "/evidence", Permission: permMine, Summary: "Kirim evidence double signing untuk slashing", Body: `{"validator": "...", "index": 1, "hash_a": "...", "signature_a": "...", "hash_b": "...", "signature_b": "..."}`, Handler: s.handleSubmitEvidence},
+		{Method: "GET", Path: "/events", Permission: permRead, Summary: "Stream event blok dan pekerjaan mining (Server-Sent Events); ?types=block,job", Handler: s.handleEvents},
+		{Method: "GET", Path: "/feed.atom", Permission: permRead, Summary: "Feed Atom berisi blok terbaru", Handler: s.handleFeed},
+		{Method: "GET", Path: "/webhooks", Permission: permAdmin, Summary: "Daftar webhook terdaftar", Handler: s.handleListWebhooks},
+		{Method: "POST", Path: "/webhooks", Permission: permAdmin, Summary: "Daftarkan webhook untuk event block, job, atau validation-failure", Body: `{"url": "https://...", "events": ["block"]}`, Handler: s.handleRegisterWebhook},
+		{Method: "GET", Path: "/debug/spans", Permission: permAdmin, Summary: "Ringkasan waktu mining, validasi, penyimpanan, dan sinkronisasi", Handler: s.handleSpans},
+		{Method: "GET", Path: "/healthz", Public: true, Summary: "Proses node hidup", Handler: s.handleHealthz},
+		{Method: "GET", Path: "/readyz", Public: true, Summary: "Node siap melayani: penyimpanan, chain sampai tip, dan antrian mining; 503 jika ada yang gagal", Handler: s.handleReadyz},
+		{Method: "GET", Path: "/openapi.json", Public: true, Summary: "Dokumen OpenAPI v3 untuk API ini", Handler: s.handleOpenAPI},
+		{Method: "GET", Path: "/docs", Public: true, Summary: "Dokumentasi API dalam HTML", Handler: s.handleDocs},
+	}
+	if s.pprof {
+		routes = append(routes, s.pprofRoutes()...)
+	}
+	if !s.readOnly {
+		return routes
+	}
+
+	var readRoutes []apiRoute
+	for _, route := range routes {
+		if route.Public || route.Permission == permRead {
+			readRoutes = append(readRoutes, route)
+		}
+	}
+	return readRoutes
+}
+
+// routes registers every endpoint of the route table with the permission it requires
+func (s *apiServer) routes() *http.ServeMux {
+	mux := http.NewServeMux()
+	for _, route := range s.routeTable() {
+		handler := route.Handler
+		if !route.Public {
+			handler = s.keys.require(route.Permission, handler)
+		}
+		mux.HandleFunc(route.Method+" "+route.Path, handler)
+	}
+	return mux
+}
+
+// Batas jumlah blok per halaman GET /blocks dan pekerjaan per halaman GET /jobs
+const (
+	defaultBlocksPageSize = 100
+	maxBlocksPageSize     = 1000
+	defaultJobsPageSize   = 100
+	maxJobsPageSize       = 1000
+)
+
+// handleListBlocks returns one page of blocks in index order. Query parameters: cursor (first
+// index, default 0 or from), limit, from and to (inclusive height range) and q (substring of
+// Data). The cursor of the next page is sent in X-Next-Cursor and a Link header.
+func (s *apiServer) handleListBlocks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	blocks := s.chain.Blocks()
+
+	from, errFrom := queryInt(query, "from", 0)
+	to, errTo := queryInt(query, "to", len(blocks)-1)
+	cursor, errCursor := queryInt(query, "cursor", from)
+	limit, errLimit := queryInt(query, "limit", defaultBlocksPageSize)
+	if errFrom != nil || errTo != nil || errCursor != nil || errLimit != nil || from < 0 || cursor < 0 || limit < 1 || limit > maxBlocksPageSize {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("parameter tidak valid: from, to, dan cursor harus >= 0, limit antara 1 dan %d", maxBlocksPageSize))
+		return
+	}
+
+	// Isi respons hanya bergantung pada tip dan query, jadi keduanya menjadi ETag
+	if notModified(w, r, blocks[len(blocks)-1].Hash+"?"+query.Encode()) {
+		return
+	}
+
+	filter := query.Get("q")
+	page := []Block{}
+	next := -1
+	for i := max(cursor, from); i < len(blocks) && i <= to; i++ {
+		if filter != "" && !strings.Contains(blocks[i].Data, filter) {
+			continue
+		}
+		if len(page) == limit {
+			next = i
+			break
+		}
+		page = append(page, blocks[i])
+	}
+
+	if next >= 0 {
+		query.Set("cursor", strconv.Itoa(next))
+		w.Header().Set("X-Next-Cursor", strconv.Itoa(next))
+		w.Header().Set("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, query.Encode()))
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *apiServer) handleGetBlock(w http.ResponseWriter, r *http.Request) {
+	index, err := strconv.Atoi(r.PathValue("index"))
+	blocks := s.chain.Blocks()
+	if err != nil || index < 0 || index >= len(blocks) {
+		writeError(w, http.StatusNotFound, "blok tidak ditemukan")
+		return
+	}
+	if notModified(w, r, blocks[index].Hash) {
+		return
+	}
+	writeJSON(w, http.StatusOK, blocks[index])
+}
+
+// queryInt parses an integer query parameter, returning fallback when it is absent
+func queryInt(query url.Values, name string, fallback int) (int, error) {
+	value := query.Get(name)
+	if value == "" {
+		return fallback, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// notModified sets an ETag derived from version and writes 304 Not Modified when the client
+// already has that version (If-None-Match)
+func notModified(w http.ResponseWriter, r *http.Request, version string) bool {
+	sum := sha256.Sum256([]byte(version))
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		if candidate = strings.TrimSpace(candidate); candidate == etag || candidate == "*" {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+func (s *apiServer) handleValidate(w http.ResponseWriter, r *http.Request) {
+	blocks := s.chain.Blocks()
+	for i := range blocks {
+		if rule, err := validateBlock(s.chain.Config(), blocks, i); err != nil {
+			writeJSON(w, http.StatusOK, map[string]any{"valid": false, "rule": rule, "error": err.Error()})
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"valid": true})
+}
+
+// handleMineBlock queues a block request and waits until it is mined
+func (s *apiServer) handleMineBlock(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.submitJob(w, r)
+	if !ok {
+		return
+	}
+
+	id := job.ID
+	job, err := s.queue.Wait(r.Context(), id)
+	if err != nil {
+		// Client memutus koneksi sebelum mining selesai
+		s.queue.Cancel(id)
+		return
+	}
+	switch {
+	case job.Status != jobDone:
+		writeError(w, http.StatusConflict, job.Error)
+	case len(job.Published) == 0:
+		writeJSON(w, http.StatusAccepted, job)
+	default:
+		writeJSON(w, http.StatusCreated, job.Published[len(job.Published)-1])
+	}
+}
+
+// handleListJobs returns one page of jobs in ID order. Query parameters: cursor (first job ID)
+// and limit; the cursor of the next page is sent in X-Next-Cursor and a Link header, as for blocks.
+func (s *apiServer) handleListJobs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	cursor, errCursor := queryInt(query, "cursor", 0)
+	limit, errLimit := queryInt(query, "limit", defaultJobsPageSize)
+	if errCursor != nil || errLimit != nil || cursor < 0 || limit < 1 || limit > maxJobsPageSize {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("parameter tidak valid: cursor harus >= 0, limit antara 1 dan %d", maxJobsPageSize))
+		return
+	}
+
+	page := []miningJob{}
+	for _, job := range s.queue.Jobs() {
+		if job.ID < cursor {
+			continue
+		}
+		if len(page) == limit {
+			query.Set("cursor", strconv.Itoa(job.ID))
+			w.Header().Set("X-Next-Cursor", strconv.Itoa(job.ID))
+			w.Header().Set("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, query.Encode()))
+			break
+		}
+		page = append(page, job)
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// handleGetJob returns a job; with ?wait=true it blocks until the job has finished
+func (s *apiServer) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	job, ok := s.queue.Job(id)
+	if err != nil || !ok {
+		writeError(w, http.StatusNotFound, "pekerjaan mining tidak ditemukan")
+		return
+	}
+	if r.URL.Query().Get("wait") == "true" {
+		if job, err = s.queue.Wait(r.Context(), id); err != nil {
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+// handleSubmitJob queues a block request and returns immediately with the job ID
+func (s *apiServer) handleSubmitJob(w http.ResponseWriter, r *http.Request) {
+	if job, ok := s.submitJob(w, r); ok {
+		writeJSON(w, http.StatusAccepted, job)
+	}
+}
+
+func (s *apiServer) handleCancelJob(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || !s.queue.Cancel(id) {
+		writeError(w, http.StatusNotFound, "pekerjaan mining tidak ditemukan")
+		return
+	}
+	job, _ := s.queue.Job(id)
+	writeJSON(w, http.StatusOK, job)
+}
+
+// submitJob decodes {"data": "...", "callback_url": "..."} and queues it; on failure the error
+// response is already written
+func (s *apiServer) submitJob(w http.ResponseWriter, r *http.Request) (miningJob, bool) {
+	var request struct {
+		Data     string `json:"data"`
+		Callback string `json:"callback_url"` // Opsional: dipanggil dengan POST saat pekerjaan selesai
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "body harus berupa JSON {\"data\": \"...\"}")
+		return miningJob{}, false
+	}
+	if request.Callback != "" {
+		// Callback membuat node mengirim request ke URL mana pun, termasuk layanan internal,
+		// jadi hanya admin yang terautentikasi boleh memakainya. Tanpa API key siapa pun yang
+		// dapat menjangkau port API akan lolos, sehingga callback ditolak sama sekali.
+		user := requestUser(r)
+		if user == nil {
+			writeError(w, http.StatusForbidden, "callback_url hanya tersedia jika API key dikonfigurasi (-api-keys)")
+			return miningJob{}, false
+		}
+		if user.level < permAdmin {
+			writeError(w, http.StatusForbidden, fmt.Sprintf("API key %s tidak memiliki permission %s untuk callback_url", user.ID, permAdmin))
+			return miningJob{}, false
+		}
+		if callback, err := url.Parse(request.Callback); err != nil || (callback.Scheme != "http" && callback.Scheme != "https") || callback.Host == "" {
+			writeError(w, http.StatusBadRequest, "callback_url harus berupa URL http atau https")
+			return miningJob{}, false
+		}
+	}
+	if err := s.chain.Config().checkDataSize(request.Data); err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, err.Error())
+		return miningJob{}, false
+	}
+	if err := checkPayload(s.chain.Store(), request.Data); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return miningJob{}, false
+	}
+
+	job, err := s.queue.Submit(request.Data, logMiningObserver{prefix: "api: "}, request.Callback)
+	if err != nil {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return miningJob{}, false
+	}
+	return job, true
+}
+
+func (s *apiServer) handleWhoAmI(w http.ResponseWriter, r *http.Request) {
+	user := requestUser(r)
+	if user == nil {
+		// Tanpa autentikasi setiap request memiliki akses penuh
+		writeJSON(w, http.StatusOK, map[string]string{"user": "", "permission": permAdmin.String()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"user": user.ID, "permission": user.level.String()})
+}
+
+func (s *apiServer) handleGetDifficulty(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]int{"difficulty": s.chain.Difficulty()})
+}
+
+func (s *apiServer) handleSetDifficulty(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Difficulty int `json:"difficulty"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Difficulty < 0 || request.Difficulty > 64 {
+		writeError(w, http.StatusBadRequest, "body harus berupa JSON {\"difficulty\": 0-64}")
+		return
+	}
+	if err := s.chain.SetDifficulty(request.Difficulty); err != nil {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"difficulty": request.Difficulty})
+}
+
+func (s *apiServer) handleListEvidence(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.chain.PendingEvidence())
+}
+
+// handleSubmitEvidence accepts double-sign evidence; it is included in the next mined block
+func (s *apiServer) handleSubmitEvidence(w http.ResponseWriter, r *http.Request) {
+	var evidence DoubleSignEvidence
+	if err := json.NewDecoder(r.Body).Decode(&evidence); err != nil {
+		writeError(w, http.StatusBadRequest, "body harus berupa JSON evidence double signing")
+		return
+	}
+	if err := s.chain.SubmitEvidence(evidence); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusAccepted, evidence)
+}
+
+// writeJSON writes v as a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(v)
+}
+
+// writeError writes a JSON error body {"error": message}
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
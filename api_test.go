@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testAPI returns the routes of an API server on an in-memory copy of the fixture chain
+func testAPI(t *testing.T, blocks int, keys *apiKeyStore) (*apiServer, http.Handler) {
+	t.Helper()
+	cfg := fixtureGenesisConfig()
+	chain := newChain(cfg, newMemoryBlockStore(), fixtureChain(cfg, 1, blocks, 1), 1)
+	s := newAPIServer(chain, newMiningQueue(chain, honestStrategy{}), keys, nil)
+	return s, s.routes()
+}
+
+func TestSubmitJobCallback(t *testing.T) {
+	keys := &apiKeyStore{keys: []apiKey{
+		{ID: "miner", Secret: "mine-secret", Permission: "mine", level: permMine},
+		{ID: "admin", Secret: "admin-secret", Permission: "admin", level: permAdmin},
+	}}
+
+	tests := []struct {
+		name     string
+		keys     *apiKeyStore
+		secret   string
+		callback string
+		want     int
+	}{
+		{name: "no callback without auth", keys: nil, want: http.StatusAccepted},
+		{name: "callback without auth", keys: nil, callback: "http://127.0.0.1:8080/", want: http.StatusForbidden},
+		{name: "callback from a miner", keys: keys, secret: "mine-secret", callback: "https://example.com/hook", want: http.StatusForbidden},
+		{name: "callback from an admin", keys: keys, secret: "admin-secret", callback: "https://example.com/hook", want: http.StatusAccepted},
+		{name: "callback that is not http", keys: keys, secret: "admin-secret", callback: "file:///etc/passwd", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, handler := testAPI(t, 2, tt.keys)
+			body := `{"data": "x", "callback_url": "` + tt.callback + `"}`
+			r := httptest.NewRequest("POST", "/jobs", strings.NewReader(body))
+			if tt.secret != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.secret)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Errorf("POST /jobs = %d %s, want %d", w.Code, strings.TrimSpace(w.Body.String()), tt.want)
+			}
+		})
+	}
+}
+
+func TestListBlocksPagination(t *testing.T) {
+	_, handler := testAPI(t, 7, nil)
+
+	tests := []struct {
+		name       string
+		query      string
+		wantIndex  []int
+		wantNext   string // Kosong berarti halaman terakhir
+		wantStatus int
+	}{
+		{name: "default page", query: "", wantIndex: []int{0, 1, 2, 3, 4, 5, 6}},
+		{name: "first page", query: "limit=3", wantIndex: []int{0, 1, 2}, wantNext: "3"},
+		{name: "next page", query: "limit=3&cursor=3", wantIndex: []int{3, 4, 5}, wantNext: "6"},
+		{name: "last full page has no next cursor", query: "limit=3&cursor=4", wantIndex: []int{4, 5, 6}},
+		{name: "cursor past the tip", query: "cursor=50", wantIndex: []int{}},
+		{name: "height range", query: "from=2&to=4", wantIndex: []int{2, 3, 4}},
+		{name: "cursor inside a range", query: "from=2&to=5&cursor=4&limit=1", wantIndex: []int{4}, wantNext: "5"},
+		{name: "cursor before the range", query: "from=3&cursor=1&limit=2", wantIndex: []int{3, 4}, wantNext: "5"},
+		{name: "limit at maximum", query: "limit=1000", wantIndex: []int{0, 1, 2, 3, 4, 5, 6}},
+		{name: "limit zero", query: "limit=0", wantStatus: http.StatusBadRequest},
+		{name: "limit above maximum", query: "limit=1001", wantStatus: http.StatusBadRequest},
+		{name: "negative cursor", query: "cursor=-1", wantStatus: http.StatusBadRequest},
+		{name: "cursor that is not a number", query: "cursor=abc", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest("GET", "/blocks?"+tt.query, nil))
+			if tt.wantStatus != 0 {
+				if w.Code != tt.wantStatus {
+					t.Fatalf("GET /blocks?%s = %d, want %d", tt.query, w.Code, tt.wantStatus)
+				}
+				return
+			}
+			var page []Block
+			if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+				t.Fatalf("GET /blocks?%s = %d %s", tt.query, w.Code, w.Body)
+			}
+			got := []int{}
+			for _, block := range page {
+				got = append(got, block.Index)
+			}
+			if !reflect.DeepEqual(got, tt.wantIndex) {
+				t.Errorf("page = %v, want %v", got, tt.wantIndex)
+			}
+			if next := w.Header().Get("X-Next-Cursor"); next != tt.wantNext {
+				t.Errorf("X-Next-Cursor = %q, want %q", next, tt.wantNext)
+			}
+			if link := w.Header().Get("Link"); (link != "") != (tt.wantNext != "") || (link != "" && !strings.Contains(link, "cursor="+tt.wantNext)) {
+				t.Errorf("Link = %q for next cursor %q", link, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestListJobsPagination(t *testing.T) {
+	s, handler := testAPI(t, 2, nil)
+	for i := 0; i < 5; i++ {
+		if _, err := s.queue.Submit("x", multiMiningObserver{}, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for query, want := range map[string][]int{
+		"limit=2":          {1, 2},
+		"limit=2&cursor=3": {3, 4},
+		"cursor=5":         {5},
+		"cursor=6":         {},
+	} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/jobs?"+query, nil))
+		var page []miningJob
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatalf("GET /jobs?%s = %d %s", query, w.Code, w.Body)
+		}
+		got := []int{}
+		for _, job := range page {
+			got = append(got, job.ID)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GET /jobs?%s = %v, want %v", query, got, want)
+		}
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/jobs?limit=1001", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("GET /jobs?limit=1001 = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestBlocksETag(t *testing.T) {
+	s, handler := testAPI(t, 3, nil)
+	get := func(target, etag string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", target, nil)
+		if etag != "" {
+			r.Header.Set("If-None-Match", etag)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	first := get("/blocks?limit=2", "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("GET /blocks = %d with ETag %q", first.Code, etag)
+	}
+	if w := get("/blocks?limit=2", etag); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("GET /blocks with matching If-None-Match = %d with %d byte body, want 304 without body", w.Code, w.Body.Len())
+	}
+	if w := get("/blocks?limit=2", `"lain", `+etag); w.Code != http.StatusNotModified {
+		t.Errorf("GET /blocks with the ETag in a list = %d, want 304", w.Code)
+	}
+	if w := get("/blocks?limit=3", etag); w.Code != http.StatusOK {
+		t.Errorf("GET /blocks with another query = %d, want 200", w.Code)
+	}
+
+	// Blok baru mengubah tip, jadi ETag lama tidak berlaku lagi
+	tip := s.chain.Tip()
+	next := mineFixtureBlock(s.chain.Config(), tip.Index+1, fixtureEpoch.Add(time.Hour), "baru", tip.Hash, 1)
+	if err := s.chain.Append(next); err != nil {
+		t.Fatal(err)
+	}
+	if w := get("/blocks?limit=2", etag); w.Code != http.StatusOK {
+		t.Errorf("GET /blocks after a new block = %d, want 200", w.Code)
+	}
+
+	block := get("/blocks/1", "")
+	if w := get("/blocks/1", block.Header().Get("ETag")); w.Code != http.StatusNotModified {
+		t.Errorf("GET /blocks/1 with matching If-None-Match = %d, want 304", w.Code)
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// attestation is a node's signed statement that its chain reached a given tip, so a submitted
+// chain can be checked against the state the node actually saw
+type attestation struct {
+	NodeID      string `json:"node_id"`
+	PublicKey   string `json:"public_key"`
+	GenesisHash string `json:"genesis_hash"`
+	Height      int    `json:"height"`
+	TipHash     string `json:"tip_hash"`
+	Timestamp   string `json:"timestamp"`
+	Signature   string `json:"signature"`
+}
+
+// message returns the bytes covered by the attestation signature
+func (a attestation) message() []byte {
+	return []byte("chain-attestation:" + a.GenesisHash + ":" + strconv.Itoa(a.Height) + ":" + a.TipHash + ":" + a.Timestamp)
+}
+
+// newAttestation signs the tip of blockchain with the node key
+func newAttestation(identity *nodeIdentity, blockchain []Block) attestation {
+	tip := blockchain[len(blockchain)-1]
+	a := attestation{
+		NodeID:      identity.ID,
+		PublicKey:   identity.PublicKey(),
+		GenesisHash: blockchain[0].Hash,
+		Height:      tip.Index,
+		TipHash:     tip.Hash,
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+	}
+	a.Signature = identity.Sign(a.message())
+	return a
+}
+
+// verify checks the signature and, if expectedNode is not empty, that the node is the expected one
+func (a attestation) verify(expectedNode string) error {
+	if expectedNode != "" && a.NodeID != expectedNode {
+		return fmt.Errorf("attestation dibuat oleh node %s, bukan %s", a.NodeID, expectedNode)
+	}
+	if a.Height < 0 {
+		return fmt.Errorf("tinggi attestation tidak valid: %d", a.Height)
+	}
+	if _, err := time.Parse(time.RFC3339, a.Timestamp); err != nil {
+		return fmt.Errorf("timestamp attestation tidak valid: %q", a.Timestamp)
+	}
+	return verifyNodeSignature(a.NodeID, a.PublicKey, a.message(), a.Signature)
+}
+
+// matchChain checks that blockchain is a valid chain that contains the attested tip
+func (a attestation) matchChain(cfg GenesisConfig, blockchain []Block) error {
+	if len(blockchain) == 0 {
+		return fmt.Errorf("blockchain lokal kosong")
+	}
+	if blockchain[0].Hash != a.GenesisHash {
+		return fmt.Errorf("genesis blockchain lokal %s, attestation untuk genesis %s", blockchain[0].Hash, a.GenesisHash)
+	}
+	if a.Height >= len(blockchain) {
+		return fmt.Errorf("blockchain lokal hanya sampai blok %d, attestation untuk blok %d", len(blockchain)-1, a.Height)
+	}
+	if blockchain[a.Height].Hash != a.TipHash {
+		return fmt.Errorf("blok %d di blockchain lokal memiliki hash %s, attestation menyebut %s", a.Height, blockchain[a.Height].Hash, a.TipHash)
+	}
+	if _, rule, err := verifyChain(cfg, blockchain[:a.Height+1], verifyStandard); err != nil {
+		return fmt.Errorf("blockchain lokal tidak valid (aturan %s): %w", rule, err)
+	}
+	return nil
+}
+
+// runAttestCommand implements "attest [-out file]": it signs the local tip with the node key
+func runAttestCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("attest", flag.ContinueOnError)
+	out := fs.String("out", "", "Tulis attestation ke file ini alih-alih stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("penggunaan: attest [-out file]")
+	}
+
+	cfg, blockchain, err := loadChain(store)
+	if err != nil {
+		return err
+	}
+	if len(blockchain) == 0 {
+		return fmt.Errorf("blockchain lokal kosong")
+	}
+	// Hanya chain yang valid yang boleh ditandatangani
+	if _, rule, err := verifyChain(cfg, blockchain, verifyStandard); err != nil {
+		return fmt.Errorf("blockchain tidak valid (aturan %s): %w", rule, err)
+	}
+	identity, err := loadNodeIdentity(store)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(newAttestation(identity, blockchain), "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if *out == "" {
+		os.Stdout.Write(data)
+		return nil
+	}
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		return err
+	}
+	fmt.Printf(Green+"Attestation blok %d ditulis ke %s.\n"+Reset, len(blockchain)-1, *out)
+	return nil
+}
+
+// runVerifyAttestationCommand implements "verify-attestation [-node id] [-chain] <file>"
+func runVerifyAttestationCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("verify-attestation", flag.ContinueOnError)
+	expectedNode := fs.String("node", "", "Node ID yang diharapkan membuat attestation")
+	checkChain := fs.Bool("chain", false, "Periksa juga bahwa blockchain di -datadir valid dan memuat blok yang di-attest")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("penggunaan: verify-attestation [-node id] [-chain] <file>")
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	var a attestation
+	if err := json.Unmarshal(data, &a); err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+	if err := a.verify(*expectedNode); err != nil {
+		return err
+	}
+
+	if *checkChain {
+		cfg, blockchain, err := loadChain(store)
+		if err != nil {
+			return err
+		}
+		if err := a.matchChain(cfg, blockchain); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf(Green+"Attestation valid: node %s mencapai blok %d (%s) pada %s.\n"+Reset, a.NodeID, a.Height, a.TipHash, a.Timestamp)
+	if *checkChain {
+		fmt.Println(Green + "Blockchain lokal memuat blok tersebut dan lolos verifikasi." + Reset)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAttestationMatchChain(t *testing.T) {
+	cfg := fixtureGenesisConfig()
+	blockchain := fixtureChain(cfg, 1, 4, 1)
+	identity, err := loadNodeIdentity(newMemoryBlockStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := fixtureChain(cfg, 2, 4, 2) // Difficulty lain memberi genesis lain
+
+	tests := []struct {
+		name     string
+		attested func() attestation
+		chain    []Block
+		node     string
+		wantErr  string // Kosong berarti attestation harus cocok
+	}{
+		{name: "tip of the same chain", attested: func() attestation { return newAttestation(identity, blockchain) }, chain: blockchain},
+		{name: "expected node", attested: func() attestation { return newAttestation(identity, blockchain) }, chain: blockchain, node: identity.ID},
+		{name: "older tip of a longer chain", attested: func() attestation { return newAttestation(identity, blockchain[:2]) }, chain: blockchain},
+		{name: "chain shorter than the attested tip", attested: func() attestation { return newAttestation(identity, blockchain) }, chain: blockchain[:2], wantErr: "hanya sampai blok 1"},
+		{name: "another node", attested: func() attestation { return newAttestation(identity, blockchain) }, chain: blockchain, node: "node-lain", wantErr: "bukan node-lain"},
+		{name: "different chain", attested: func() attestation { return newAttestation(identity, other) }, chain: blockchain, wantErr: "genesis blockchain lokal"},
+		{
+			name: "different block at the attested height",
+			attested: func() attestation {
+				forked := append(append([]Block(nil), blockchain[:2]...), other[2])
+				return newAttestation(identity, forked)
+			},
+			chain:   blockchain,
+			wantErr: "blok 2 di blockchain lokal",
+		},
+		{
+			name: "tip hash changed after signing",
+			attested: func() attestation {
+				a := newAttestation(identity, blockchain)
+				a.TipHash = blockchain[2].Hash
+				return a
+			},
+			chain:   blockchain,
+			wantErr: "tanda tangan",
+		},
+		{
+			name: "negative height",
+			attested: func() attestation {
+				a := newAttestation(identity, blockchain)
+				a.Height = -1
+				a.Signature = identity.Sign(a.message())
+				return a
+			},
+			chain:   blockchain,
+			wantErr: "tinggi attestation tidak valid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.attested()
+			err := a.verify(tt.node)
+			if err == nil {
+				err = a.matchChain(cfg, tt.chain)
+			}
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("attestation rejected: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
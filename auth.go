@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// permission is the access level granted to an API key
+type permission int
+
+const (
+	permRead  permission = iota // Hanya endpoint baca
+	permMine                    // Baca dan mining blok baru
+	permAdmin                   // Semua endpoint termasuk pengaturan node
+)
+
+// apiKeyContextKey stores the authenticated *apiKey in the request context
+type apiKeyContextKey struct{}
+
+// maxSignatureAge is how far X-Timestamp of an HMAC-signed request may drift from the server clock
+const maxSignatureAge = 5 * time.Minute
+
+// maxSignedBodySize bounds the body read to check an HMAC signature, before the client is authenticated
+const maxSignedBodySize = 1 << 20
+
+// parsePermission converts the permission name used in the key file
+func parsePermission(name string) (permission, error) {
+	switch name {
+	case "read":
+		return permRead, nil
+	case "mine":
+		return permMine, nil
+	case "admin":
+		return permAdmin, nil
+	default:
+		return 0, fmt.Errorf("permission tidak dikenal: %q (gunakan read, mine, atau admin)", name)
+	}
+}
+
+func (p permission) String() string {
+	switch p {
+	case permRead:
+		return "read"
+	case permMine:
+		return "mine"
+	case permAdmin:
+		return "admin"
+	default:
+		return "permission(" + strconv.Itoa(int(p)) + ")"
+	}
+}
+
+// apiKey is one entry of the API key file; on a classroom server each
+// entry is a named user (the id) with its own permission level
+type apiKey struct {
+	ID         string `json:"id"`
+	Secret     string `json:"secret"`
+	Permission string `json:"permission"`
+
+	level permission
+}
+
+// apiKeyStore authenticates API requests; a nil store disables authentication
+type apiKeyStore struct {
+	keys []apiKey
+
+	mu   sync.Mutex
+	used map[string]time.Time // Tanda tangan request non-GET yang sudah diterima, untuk menolak replay
+}
+
+// loadAPIKeys reads a JSON array of {"id", "secret", "permission"} objects
+func loadAPIKeys(path string) (*apiKeyStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []apiKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	seen := make(map[string]bool)
+	for i := range keys {
+		if keys[i].ID == "" || keys[i].Secret == "" {
+			return nil, fmt.Errorf("%s: API key ke-%d harus memiliki id dan secret", path, i+1)
+		}
+		if seen[keys[i].ID] {
+			return nil, fmt.Errorf("%s: id %q digunakan lebih dari sekali", path, keys[i].ID)
+		}
+		seen[keys[i].ID] = true
+		level, err := parsePermission(keys[i].Permission)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		keys[i].level = level
+	}
+	return &apiKeyStore{keys: keys}, nil
+}
+
+// authenticate identifies the key used by r, either as a static bearer
+// secret or through an HMAC-SHA256 signature over the request
+func (s *apiKeyStore) authenticate(w http.ResponseWriter, r *http.Request) (*apiKey, error) {
+	// Static API key: "Authorization: Bearer <secret>"
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		secret := strings.TrimPrefix(auth, "Bearer ")
+		for i := range s.keys {
+			if subtle.ConstantTimeCompare([]byte(s.keys[i].Secret), []byte(secret)) == 1 {
+				return &s.keys[i], nil
+			}
+		}
+		return nil, fmt.Errorf("API key tidak valid")
+	}
+
+	// HMAC: X-Api-Key-Id, X-Timestamp (unix detik), X-Signature
+	keyID := r.Header.Get("X-Api-Key-Id")
+	if keyID == "" {
+		return nil, fmt.Errorf("autentikasi diperlukan")
+	}
+	var key *apiKey
+	for i := range s.keys {
+		if s.keys[i].ID == keyID {
+			key = &s.keys[i]
+			break
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("API key tidak valid")
+	}
+
+	timestamp := r.Header.Get("X-Timestamp")
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("X-Timestamp tidak valid")
+	}
+	if age := time.Since(time.Unix(unix, 0)); age > maxSignatureAge || age < -maxSignatureAge {
+		return nil, fmt.Errorf("X-Timestamp kedaluwarsa")
+	}
+
+	// Body dibaca untuk tanda tangan lalu dikembalikan agar handler tetap bisa membacanya
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSignedBodySize))
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	expected := signRequest(key.Secret, r.Method, r.URL.RequestURI(), timestamp, body)
+	signature, err := hex.DecodeString(r.Header.Get("X-Signature"))
+	if err != nil || !hmac.Equal(signature, expected) {
+		return nil, fmt.Errorf("X-Signature tidak valid")
+	}
+
+	// Request yang mengubah state hanya boleh dipakai sekali selama masih dalam jendela X-Timestamp;
+	// client yang mengirim request identik dalam detik yang sama perlu membedakan URL-nya
+	if r.Method != http.MethodGet && r.Method != http.MethodHead && !s.markUsed(hex.EncodeToString(signature)) {
+		return nil, fmt.Errorf("request bertanda tangan ini sudah pernah diterima (replay)")
+	}
+	return key, nil
+}
+
+// markUsed records a signature and reports whether it was new; signatures are forgotten once
+// their timestamp can no longer pass the maxSignatureAge check
+func (s *apiKeyStore) markUsed(signature string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.used == nil {
+		s.used = make(map[string]time.Time)
+	}
+	for used, at := range s.used {
+		if now.Sub(at) > 2*maxSignatureAge {
+			delete(s.used, used)
+		}
+	}
+	if _, ok := s.used[signature]; ok {
+		return false
+	}
+	s.used[signature] = now
+	return true
+}
+
+// signRequest computes the HMAC a client must send in X-Signature (hex encoded)
+func signRequest(secret, method, requestURI, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(method + "\n" + requestURI + "\n" + timestamp + "\n"))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// require wraps handler so it only runs for requests authenticated with at least the given permission
+func (s *apiKeyStore) require(level permission, handler http.HandlerFunc) http.HandlerFunc {
+	if s == nil {
+		return handler
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		key, err := s.authenticate(w, r)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("body request bertanda tangan melebihi %d byte", maxSignedBodySize))
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+		if key.level < level {
+			writeError(w, http.StatusForbidden, fmt.Sprintf("API key %s tidak memiliki permission %s", key.ID, level))
+			return
+		}
+		handler(w, r.WithContext(context.WithValue(r.Context(), apiKeyContextKey{}, key)))
+	}
+}
+
+// requestUser returns the key that authenticated r, or nil when authentication is disabled
+func requestUser(r *http.Request) *apiKey {
+	key, _ := r.Context().Value(apiKeyContextKey{}).(*apiKey)
+	return key
+}
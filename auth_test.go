@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testKeyStore has one key per permission level
+func testKeyStore() *apiKeyStore {
+	return &apiKeyStore{keys: []apiKey{
+		{ID: "reader", Secret: "read-secret", Permission: "read", level: permRead},
+		{ID: "miner", Secret: "mine-secret", Permission: "mine", level: permMine},
+	}}
+}
+
+// signedRequest builds a request carrying the HMAC headers for keyID and secret at timestamp
+func signedRequest(method, target, body, keyID, secret, timestamp string) *http.Request {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	r.Header.Set("X-Api-Key-Id", keyID)
+	r.Header.Set("X-Timestamp", timestamp)
+	r.Header.Set("X-Signature", hex.EncodeToString(signRequest(secret, method, r.URL.RequestURI(), timestamp, []byte(body))))
+	return r
+}
+
+func TestAuthenticate(t *testing.T) {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	expired := strconv.FormatInt(time.Now().Add(-2*maxSignatureAge).Unix(), 10)
+
+	tests := []struct {
+		name    string
+		request func() *http.Request
+		wantID  string // Kosong berarti autentikasi harus gagal
+	}{
+		{
+			name: "bearer secret",
+			request: func() *http.Request {
+				r := httptest.NewRequest("GET", "/blocks", nil)
+				r.Header.Set("Authorization", "Bearer mine-secret")
+				return r
+			},
+			wantID: "miner",
+		},
+		{
+			name: "wrong bearer secret",
+			request: func() *http.Request {
+				r := httptest.NewRequest("GET", "/blocks", nil)
+				r.Header.Set("Authorization", "Bearer mine-secretx")
+				return r
+			},
+		},
+		{
+			name:    "no credentials",
+			request: func() *http.Request { return httptest.NewRequest("GET", "/blocks", nil) },
+		},
+		{
+			name:    "hmac signed get",
+			request: func() *http.Request { return signedRequest("GET", "/blocks?limit=5", "", "reader", "read-secret", now) },
+			wantID:  "reader",
+		},
+		{
+			name: "hmac signed post",
+			request: func() *http.Request {
+				return signedRequest("POST", "/jobs", `{"data":"x"}`, "miner", "mine-secret", now)
+			},
+			wantID: "miner",
+		},
+		{
+			name:    "hmac with another key's secret",
+			request: func() *http.Request { return signedRequest("GET", "/blocks", "", "miner", "read-secret", now) },
+		},
+		{
+			name:    "hmac unknown key id",
+			request: func() *http.Request { return signedRequest("GET", "/blocks", "", "mallory", "read-secret", now) },
+		},
+		{
+			name:    "hmac expired timestamp",
+			request: func() *http.Request { return signedRequest("GET", "/blocks", "", "reader", "read-secret", expired) },
+		},
+		{
+			name:    "hmac malformed timestamp",
+			request: func() *http.Request { return signedRequest("GET", "/blocks", "", "reader", "read-secret", "kemarin") },
+		},
+		{
+			name: "hmac body changed after signing",
+			request: func() *http.Request {
+				signed := signedRequest("POST", "/jobs", `{"data":"x"}`, "miner", "mine-secret", now)
+				r := httptest.NewRequest("POST", "/jobs", strings.NewReader(`{"data":"y"}`))
+				r.Header = signed.Header
+				return r
+			},
+		},
+		{
+			name: "hmac signature for another path",
+			request: func() *http.Request {
+				signed := signedRequest("GET", "/blocks", "", "reader", "read-secret", now)
+				r := httptest.NewRequest("GET", "/jobs", nil)
+				r.Header = signed.Header
+				return r
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := testKeyStore().authenticate(httptest.NewRecorder(), tt.request())
+			if tt.wantID == "" {
+				if err == nil {
+					t.Fatalf("authenticate accepted the request as %q", key.ID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("authenticate: %v", err)
+			}
+			if key.ID != tt.wantID {
+				t.Errorf("authenticated as %q, want %q", key.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+// TestAuthenticateReplay checks that a signed state-changing request is only accepted once,
+// while a signed GET may be repeated
+func TestAuthenticateReplay(t *testing.T) {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	for _, method := range []string{"GET", "POST"} {
+		store := testKeyStore()
+		first := signedRequest(method, "/jobs", "", "miner", "mine-secret", now)
+		if _, err := store.authenticate(httptest.NewRecorder(), first); err != nil {
+			t.Fatalf("%s: first request: %v", method, err)
+		}
+		_, err := store.authenticate(httptest.NewRecorder(), signedRequest(method, "/jobs", "", "miner", "mine-secret", now))
+		if method == "GET" && err != nil {
+			t.Errorf("repeated GET rejected: %v", err)
+		}
+		if method == "POST" && err == nil {
+			t.Errorf("replayed POST accepted")
+		}
+	}
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// backupManifestFile records which blocks a backup target already holds
+const backupManifestFile = "backup-manifest.json"
+
+// backupManifest lists the hash of every backed-up block by index, so a backup after a
+// rollback re-uploads the blocks from the point where the chain diverged
+type backupManifest struct {
+	Hashes  []string          `json:"hashes"`
+	Schemas map[string]string `json:"schemas,omitempty"` // Nama file schema -> SHA-256 isinya
+	Updated time.Time         `json:"updated"`
+}
+
+// backupTarget stores backup files in a directory or an object store
+type backupTarget interface {
+	// Get returns an error satisfying os.IsNotExist when the file does not exist
+	Get(name string) ([]byte, error)
+	Put(name string, data []byte) error
+	String() string
+}
+
+// openBackupTarget parses a target: a directory path or s3://bucket/prefix. S3 credentials
+// come from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_REGION (default us-east-1);
+// S3_ENDPOINT selects an S3-compatible server such as MinIO (default AWS).
+func openBackupTarget(target string) (backupTarget, error) {
+	if !strings.HasPrefix(target, "s3://") {
+		return dirBackupTarget{dir: target}, nil
+	}
+	bucket, prefix, _ := strings.Cut(strings.TrimPrefix(target, "s3://"), "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("target S3 harus berbentuk s3://bucket/prefix")
+	}
+	s3 := &s3BackupTarget{
+		endpoint:  os.Getenv("S3_ENDPOINT"),
+		region:    os.Getenv("AWS_REGION"),
+		accessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+		secretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		bucket:    bucket,
+		prefix:    strings.Trim(prefix, "/"),
+	}
+	if s3.accessKey == "" || s3.secretKey == "" {
+		return nil, fmt.Errorf("AWS_ACCESS_KEY_ID dan AWS_SECRET_ACCESS_KEY harus diisi untuk backup S3")
+	}
+	if s3.region == "" {
+		s3.region = "us-east-1"
+	}
+	if s3.endpoint == "" {
+		s3.endpoint = "https://s3." + s3.region + ".amazonaws.com"
+	}
+	return s3, nil
+}
+
+// dirBackupTarget keeps backups in a second directory, e.g. on an external disk
+type dirBackupTarget struct {
+	dir string
+}
+
+func (t dirBackupTarget) Get(name string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(t.dir, name))
+}
+
+func (t dirBackupTarget) Put(name string, data []byte) error {
+	if err := os.MkdirAll(t.dir, os.ModePerm); err != nil {
+		return err
+	}
+	path := filepath.Join(t.dir, name)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (t dirBackupTarget) String() string { return t.dir }
+
+// s3BackupTarget keeps backups in an S3-compatible bucket using path-style requests
+// signed with AWS Signature Version 4
+type s3BackupTarget struct {
+	endpoint  string
+	region    string
+	accessKey string
+	secretKey string
+	bucket    string
+	prefix    string
+}
+
+func (t *s3BackupTarget) String() string { return "s3://" + t.bucket + "/" + t.prefix }
+
+// objectURL returns the path-style URL of a backup file
+func (t *s3BackupTarget) objectURL(name string) (*url.URL, error) {
+	key := name
+	if t.prefix != "" {
+		key = t.prefix + "/" + name
+	}
+	return url.Parse(strings.TrimRight(t.endpoint, "/") + "/" + t.bucket + "/" + key)
+}
+
+func (t *s3BackupTarget) Get(name string) ([]byte, error) {
+	resp, err := t.do(http.MethodGet, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, &fs.PathError{Op: "get", Path: name, Err: fs.ErrNotExist}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("S3 GET %s: %s", name, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (t *s3BackupTarget) Put(name string, data []byte) error {
+	resp, err := t.do(http.MethodPut, name, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("S3 PUT %s: %s", name, resp.Status)
+	}
+	return nil
+}
+
+// do sends a signed request for one object
+func (t *s3BackupTarget) do(method, name string, body []byte) (*http.Response, error) {
+	u, err := t.objectURL(name)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	t.sign(req, body, time.Now().UTC())
+	client := &http.Client{Timeout: 30 * time.Second}
+	return client.Do(req)
+}
+
+// sign adds the AWS Signature Version 4 headers for the s3 service to req
+func (t *s3BackupTarget) sign(req *http.Request, body []byte, now time.Time) {
+	payloadHash := sha256.Sum256(body)
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"",
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + hex.EncodeToString(payloadHash[:]),
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	canonicalHash := sha256.Sum256([]byte(canonical))
+	scope := date + "/" + t.region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	key := []byte("AWS4" + t.secretKey)
+	for _, part := range []string{date, t.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", t.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// Backup copies genesis.json, changed payload schemas and every block the target does not
+// hold yet, byte for byte, then updates the manifest; it returns the number of blocks uploaded
+func (s fileBlockStore) Backup(target backupTarget) (int, error) {
+	blocks, err := s.LoadBlocks()
+	if err != nil {
+		return 0, err
+	}
+
+	var manifest backupManifest
+	data, err := target.Get(backupManifestFile)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return 0, fmt.Errorf("%s: %w", backupManifestFile, err)
+		}
+	}
+
+	// Blok yang sudah ada di target dilewati sampai titik chain berbeda (misalnya setelah rollback)
+	from := 0
+	for from < len(blocks) && from < len(manifest.Hashes) && manifest.Hashes[from] == blocks[from].Hash {
+		from++
+	}
+	if from == 0 || from < len(blocks) {
+		// Salt storage key ikut dicadangkan; tanpanya blok terenkripsi tidak dapat dibuka
+		for _, name := range []string{genesisConfigFile, storageKeyFile} {
+			raw, err := s.ReadFile(name)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return 0, err
+			}
+			if err := target.Put(name, raw); err != nil {
+				return 0, err
+			}
+		}
+	}
+	for _, block := range blocks[from:] {
+		name := fmt.Sprintf("block%d.json", block.Index)
+		raw, err := s.ReadFile(name)
+		if err != nil {
+			return 0, err
+		}
+		if err := target.Put(name, raw); err != nil {
+			return 0, err
+		}
+	}
+
+	// Schema payload dapat berubah tanpa blok baru, jadi dibandingkan lewat hash isinya
+	names, err := s.schemaFiles()
+	if err != nil {
+		return 0, err
+	}
+	schemas := make(map[string]string, len(names))
+	schemasChanged := len(names) != len(manifest.Schemas)
+	for _, name := range names {
+		raw, err := s.ReadFile(name)
+		if err != nil {
+			return 0, err
+		}
+		sum := sha256.Sum256(raw)
+		schemas[name] = hex.EncodeToString(sum[:])
+		if manifest.Schemas[name] == schemas[name] {
+			continue
+		}
+		schemasChanged = true
+		if err := target.Put(name, raw); err != nil {
+			return 0, err
+		}
+	}
+	if from == len(blocks) && len(manifest.Hashes) == len(blocks) && !schemasChanged {
+		return 0, nil
+	}
+
+	manifest.Hashes = manifest.Hashes[:0]
+	for _, block := range blocks {
+		manifest.Hashes = append(manifest.Hashes, block.Hash)
+	}
+	manifest.Schemas = schemas
+	manifest.Updated = time.Now()
+	data, err = json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	return len(blocks) - from, target.Put(backupManifestFile, data)
+}
+
+// runBackups backs the chain up to target every interval until ctx ends
+func runBackups(ctx context.Context, store fileBlockStore, target backupTarget, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := store.Backup(target); err != nil {
+				fmt.Printf(Yellow+"Backup ke %s gagal: %v\n"+Reset, target, err)
+			}
+		}
+	}
+}
+
+// runBackupCommand implements "backup <dir|s3://bucket/prefix>"
+func runBackupCommand(store BlockStore, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("penggunaan: backup <dir|s3://bucket/prefix>")
+	}
+	files, ok := onDiskStore(store)
+	if !ok {
+		return fmt.Errorf("backup hanya tersedia untuk chain di disk")
+	}
+	target, err := openBackupTarget(args[0])
+	if err != nil {
+		return err
+	}
+	uploaded, err := files.Backup(target)
+	if err != nil {
+		return err
+	}
+	if uploaded == 0 {
+		fmt.Printf(Green+"Backup di %s sudah mutakhir, tidak ada blok baru.\n"+Reset, target)
+		return nil
+	}
+	fmt.Printf(Green+"%d blok di-backup ke %s.\n"+Reset, uploaded, target)
+	return nil
+}
+
+// runRestoreCommand implements "restore [-force] <dir|s3://bucket/prefix>": it writes the
+// backed-up chain into -datadir and verifies it
+func runRestoreCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	force := fs.Bool("force", false, "Timpa blok yang sudah ada di -datadir")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("penggunaan: restore [-force] <dir|s3://bucket/prefix>")
+	}
+	files, ok := store.(fileBlockStore)
+	if !ok {
+		return fmt.Errorf("restore hanya tersedia untuk chain di disk yang dapat ditulis")
+	}
+	target, err := openBackupTarget(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	existing, err := files.LoadBlocks()
+	if err != nil && !*force {
+		return err
+	}
+	if len(existing) > 0 && !*force {
+		return fmt.Errorf("%s sudah berisi %d blok; gunakan -force untuk menimpanya", files.dir, len(existing))
+	}
+
+	data, err := target.Get(backupManifestFile)
+	if err != nil {
+		return fmt.Errorf("%s bukan backup chain: %w", target, err)
+	}
+	var manifest backupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("%s: %w", backupManifestFile, err)
+	}
+
+	// Blok lama di atas tinggi backup dihapus agar chain tidak tercampur
+	for _, block := range existing {
+		if block.Index >= len(manifest.Hashes) {
+			if err := files.Remove(fmt.Sprintf("block%d.json", block.Index)); err != nil {
+				return err
+			}
+		}
+	}
+	names := []string{genesisConfigFile, storageKeyFile}
+	for name := range manifest.Schemas {
+		if !isSchemaFile(name) {
+			return fmt.Errorf("%s: nama file schema tidak valid: %q", backupManifestFile, name)
+		}
+		names = append(names, name)
+	}
+	for i := range manifest.Hashes {
+		names = append(names, fmt.Sprintf("block%d.json", i))
+	}
+	for _, name := range names {
+		raw, err := target.Get(name)
+		if os.IsNotExist(err) && (name == genesisConfigFile || name == storageKeyFile) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := files.WriteFile(name, raw, 0644); err != nil {
+			return err
+		}
+		// Blok backup dienkripsi dengan salt dari backup, bukan salt lokal
+		if name == storageKeyFile && files.cipher != nil {
+			if err := files.cipher.reload(files); err != nil {
+				return err
+			}
+		}
+	}
+	files.Remove(miningStateFile)
+
+	cfg, blockchain, err := loadChain(store)
+	if err != nil {
+		return err
+	}
+	if _, rule, err := verifyChain(cfg, blockchain, verifyStandard); err != nil {
+		return fmt.Errorf("chain hasil restore tidak valid (aturan %s): %v", rule, err)
+	}
+	fmt.Printf(Green+"%d blok dipulihkan dari %s ke %s (backup %s).\n"+Reset, len(blockchain), target, files.dir, manifest.Updated.Format("2006-01-02 15:04:05"))
+	return nil
+}
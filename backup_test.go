@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// countingTarget records the names written to a directory backup target
+type countingTarget struct {
+	dirBackupTarget
+	puts []string
+}
+
+func (t *countingTarget) Put(name string, data []byte) error {
+	t.puts = append(t.puts, name)
+	return t.dirBackupTarget.Put(name, data)
+}
+
+func TestBackupManifest(t *testing.T) {
+	cfg := fixtureGenesisConfig()
+	blockchain := fixtureChain(cfg, 1, 5, 1)
+	fork := fixtureChain(cfg, 2, 4, 1) // Genesis sama, blok 1 dan seterusnya berbeda
+	store := testStore(t, blockchain[:3])
+	if err := store.WriteFile(genesisConfigFile, []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	target := &countingTarget{dirBackupTarget: dirBackupTarget{dir: t.TempDir()}}
+
+	steps := []struct {
+		name     string
+		change   func()
+		uploaded int
+		puts     []string
+	}{
+		{
+			name:     "first backup",
+			change:   func() {},
+			uploaded: 3,
+			puts:     []string{"backup-manifest.json", "block0.json", "block1.json", "block2.json", "genesis.json"},
+		},
+		{
+			name:   "nothing changed",
+			change: func() {},
+		},
+		{
+			name:     "new blocks",
+			change:   func() { store.SaveBlock(blockchain[3]); store.SaveBlock(blockchain[4]) },
+			uploaded: 2,
+			puts:     []string{"backup-manifest.json", "block3.json", "block4.json", "genesis.json"},
+		},
+		{
+			name: "rollback to a fork",
+			change: func() {
+				store.Remove("block4.json")
+				for _, block := range fork[1:] {
+					store.SaveBlock(block)
+				}
+			},
+			uploaded: 3,
+			puts:     []string{"backup-manifest.json", "block1.json", "block2.json", "block3.json", "genesis.json"},
+		},
+		{
+			name:   "schema registered",
+			change: func() { store.WriteFile(schemaFile("order"), []byte(`{"required": ["sku"]}`), 0644) },
+			puts:   []string{"backup-manifest.json", "schema-order.json"},
+		},
+		{
+			name:   "schema unchanged",
+			change: func() {},
+		},
+	}
+
+	for _, step := range steps {
+		step.change()
+		target.puts = nil
+		uploaded, err := store.Backup(target)
+		if err != nil {
+			t.Fatalf("%s: Backup: %v", step.name, err)
+		}
+		sort.Strings(target.puts)
+		if uploaded != step.uploaded || !reflect.DeepEqual(target.puts, step.puts) {
+			t.Errorf("%s: uploaded %d blocks writing %v, want %d writing %v", step.name, uploaded, target.puts, step.uploaded, step.puts)
+		}
+
+		data, err := target.Get(backupManifestFile)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		var manifest backupManifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			t.Fatalf("%s: %s: %v", step.name, backupManifestFile, err)
+		}
+		if want := blockHashes(t, store); !reflect.DeepEqual(manifest.Hashes, want) {
+			t.Errorf("%s: manifest hashes = %v, want %v", step.name, manifest.Hashes, want)
+		}
+	}
+
+	// Restore dari target menghasilkan chain yang sama dengan chain terakhir yang dicadangkan
+	restored := fileBlockStore{dir: t.TempDir()}
+	if err := runRestoreCommand(restored, []string{target.dir}); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if got, want := blockHashes(t, restored), blockHashes(t, store); !reflect.DeepEqual(got, want) {
+		t.Errorf("restored blocks = %v, want %v", got, want)
+	}
+	if _, err := loadPayloadSchema(restored, "order"); err != nil {
+		t.Errorf("schema not restored: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+// blockField is one field of the canonical block encoding with its position in the hashed bytes
+type blockField struct {
+	Name   string `json:"name"`
+	Offset int    `json:"offset"`
+	Length int    `json:"length"`
+	Value  string `json:"value"`
+	Hex    string `json:"hex"`
+}
+
+// blockRecordFields returns the fields concatenated into the bytes that are hashed, in order
+func blockRecordFields(block Block) []blockField {
+	values := []struct{ name, value string }{
+		{"index", strconv.Itoa(block.Index)},
+		{"timestamp", block.Timestamp},
+		{"data", block.Data},
+		{"nonce", strconv.FormatUint(block.Nonce, 10)},
+		{"previous_hash", block.PreviousHash},
+	}
+	// Extra data hanya di-hash jika ada, sehingga hash blok lama tetap sama
+	if block.ExtraData != "" {
+		values = append(values, struct{ name, value string }{"extra_data", block.ExtraData})
+	}
+	// Evidence slashing di-hash sebagai digest, juga hanya jika ada
+	if len(block.Evidence) > 0 {
+		values = append(values, struct{ name, value string }{"evidence", evidenceDigest(block.Evidence)})
+	}
+
+	fields := make([]blockField, 0, len(values))
+	offset := 0
+	for _, v := range values {
+		fields = append(fields, blockField{Name: v.name, Offset: offset, Length: len(v.value), Value: v.value, Hex: hex.EncodeToString([]byte(v.value))})
+		offset += len(v.value)
+	}
+	return fields
+}
+
+// blockRecord returns the canonical encoding of a block: exactly the bytes covered by its hash
+func blockRecord(block Block) []byte {
+	var record []byte
+	for _, field := range blockRecordFields(block) {
+		record = append(record, field.Value...)
+	}
+	return record
+}
+
+// blockSize returns the size in bytes of the block file fileBlockStore writes for block; with
+// storage encryption the file is encryptedRecordOverhead bytes larger
+func blockSize(block Block) int {
+	data, _ := encodeBlockFile(block)
+	return len(data)
+}
+
+// printBlockSize prints the size of block and, when the chain limits block data, how close
+// the data is to that limit
+func printBlockSize(cfg GenesisConfig, block Block) {
+	limit := ""
+	if cfg.MaxDataSize > 0 {
+		limit = fmt.Sprintf(" dari batas %d", cfg.MaxDataSize)
+	}
+	fmt.Printf("%sUkuran        :%s %d byte (data %d byte%s)\n", BoldCyan, Reset, blockSize(block), len(block.Data), limit)
+	if warning := cfg.dataSizeWarning(len(block.Data)); warning != "" {
+		fmt.Println(Yellow + "Peringatan: " + warning + Reset)
+	}
+}
+
+// blockDetail is a block in three forms: parsed, raw canonical bytes, and field by field
+type blockDetail struct {
+	Block     Block        `json:"block"`
+	Raw       string       `json:"raw"`        // Hex dari byte yang di-hash
+	PoW       string       `json:"pow"`        // Algoritma proof-of-work yang meng-hash byte tersebut
+	Hash      string       `json:"hash"`       // Hash yang dihitung ulang dari raw
+	HashValid bool         `json:"hash_valid"` // Hash yang dihitung ulang sama dengan hash blok
+	Fields    []blockField `json:"fields"`     // Rincian raw per field beserta offset byte
+	NotHashed []string     `json:"not_hashed"` // Field blok yang tidak tercakup hash
+	Size      int          `json:"size"`       // Ukuran file blok dalam byte (JSON berindentasi, tanpa enkripsi)
+	DataSize  int          `json:"data_size"`  // Ukuran field data yang dibatasi max_data_size
+	Warning   string       `json:"warning,omitempty"`
+}
+
+// newBlockDetail decomposes block of a chain with genesis config cfg into its canonical encoding
+func newBlockDetail(cfg GenesisConfig, block Block) blockDetail {
+	hash := calculateHash(cfg, block)
+	return blockDetail{
+		Block:     block,
+		Raw:       hex.EncodeToString(blockRecord(block)),
+		PoW:       cfg.PoW,
+		Hash:      hash,
+		HashValid: hash == block.Hash,
+		Fields:    blockRecordFields(block),
+		NotHashed: []string{"hash", "difficulty", "signatures"},
+		Size:      blockSize(block),
+		DataSize:  len(block.Data),
+		Warning:   cfg.dataSizeWarning(len(block.Data)),
+	}
+}
+
+// preimageColors distinguishes consecutive fields in the printed preimage
+var preimageColors = []string{BoldCyan, BoldYellow, BoldGreen, Magenta, BoldBlue}
+
+// printBlockDetail prints the annotated breakdown of a block of a chain with genesis config cfg
+func printBlockDetail(cfg GenesisConfig, detail blockDetail) {
+	fmt.Printf(BoldYellow+"\n=== Blok %d ===\n"+Reset, detail.Block.Index)
+	fmt.Printf("%s%-8s %-6s %-14s%s %s\n", BoldCyan, "Offset", "Byte", "Field", Reset, "Nilai")
+	for i, field := range detail.Fields {
+		color := preimageColors[i%len(preimageColors)]
+		fmt.Printf("%-8d %-6d %s%-14s%s %q\n", field.Offset, field.Length, color, field.Name, Reset, field.Value)
+		fmt.Printf("%-29s %s%s%s\n", "", Blue, field.Hex, Reset)
+	}
+
+	// Preimage ditampilkan apa adanya, tiap field dengan warnanya sendiri
+	fmt.Printf("%sPreimage:%s ", BoldCyan, Reset)
+	for i, field := range detail.Fields {
+		fmt.Print(preimageColors[i%len(preimageColors)] + field.Value + Reset)
+	}
+	fmt.Println()
+	fmt.Printf("%sRaw (%d byte):%s %s\n", BoldCyan, len(detail.Raw)/2, Reset, detail.Raw)
+	fmt.Printf("%s%s(raw) =%s %s\n", BoldCyan, detail.PoW, Reset, detail.Hash)
+	if detail.HashValid {
+		fmt.Println(Green + "Hash sama dengan hash yang tersimpan di blok." + Reset)
+	} else {
+		fmt.Printf(Red+"Hash berbeda dengan hash yang tersimpan di blok: %s\n"+Reset, detail.Block.Hash)
+	}
+	fmt.Printf("Tidak termasuk hash: %v\n", detail.NotHashed)
+	printBlockSize(cfg, detail.Block)
+	printDifficultyTarget(detail.Block)
+	if detail.PoW == powSHA256 {
+		fmt.Printf("Periksa sendiri: %s%s block %d -raw | sha256sum%s\n", Cyan, os.Args[0], detail.Block.Index, Reset)
+	}
+}
+
+// runBlockCommand implements "block <index> [-json|-raw]"; -raw writes exactly the hashed bytes
+func runBlockCommand(store BlockStore, args []string) error {
+	if len(args) < 1 || len(args) > 2 || (len(args) == 2 && args[1] != "-json" && args[1] != "-raw") {
+		return fmt.Errorf("penggunaan: block <index> [-json|-raw]")
+	}
+	index, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("index harus berupa angka")
+	}
+
+	cfg, blocks, err := loadChain(store)
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(blocks) {
+		return fmt.Errorf("blok %d tidak ditemukan", index)
+	}
+
+	if len(args) == 2 && args[1] == "-raw" {
+		_, err := os.Stdout.Write(blockRecord(blocks[index]))
+		return err
+	}
+	detail := newBlockDetail(cfg, blocks[index])
+	if len(args) == 2 {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(detail)
+	}
+	printBlockDetail(cfg, detail)
+	return nil
+}
+
+func (s *apiServer) handleGetBlockDetail(w http.ResponseWriter, r *http.Request) {
+	index, err := strconv.Atoi(r.PathValue("index"))
+	blocks := s.chain.Blocks()
+	if err != nil || index < 0 || index >= len(blocks) {
+		writeError(w, http.StatusNotFound, "blok tidak ditemukan")
+		return
+	}
+	if notModified(w, r, "detail:"+blocks[index].Hash) {
+		return
+	}
+	writeJSON(w, http.StatusOK, newBlockDetail(s.chain.Config(), blocks[index]))
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Chain holds the in-memory blockchain shared by the CLI menu and the API server
+type Chain struct {
+	config GenesisConfig // Tidak berubah selama chain berjalan
+	store  BlockStore
+
+	mu         sync.RWMutex
+	blocks     []Block
+	difficulty int
+	evidence   []DoubleSignEvidence // Bukti double signing yang menunggu dimasukkan ke blok
+
+	events eventBus // Event blok baru untuk SSE dan subscriber lain
+}
+
+// newChain wraps blocks already loaded from store; difficulty is used for the next mined block
+func newChain(cfg GenesisConfig, store BlockStore, blocks []Block, difficulty int) *Chain {
+	return &Chain{config: cfg, store: store, blocks: blocks, difficulty: difficulty}
+}
+
+// Config returns the genesis config of the chain
+func (c *Chain) Config() GenesisConfig {
+	return c.config
+}
+
+// Store returns the block store the chain persists to
+func (c *Chain) Store() BlockStore {
+	return c.store
+}
+
+// Blocks returns a copy of every block so callers can read it without holding the lock
+func (c *Chain) Blocks() []Block {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]Block(nil), c.blocks...)
+}
+
+// Len returns the number of blocks in the chain
+func (c *Chain) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.blocks)
+}
+
+// Tip returns the last block of the chain
+func (c *Chain) Tip() Block {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.blocks[len(c.blocks)-1]
+}
+
+// Difficulty returns the difficulty used for the next mined block, as required
+// by the retarget algorithm or as set manually
+func (c *Chain) Difficulty() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if difficulty, ok := nextDifficulty(c.config, c.blocks); ok {
+		return difficulty
+	}
+	return c.difficulty
+}
+
+// SetDifficulty changes the difficulty used for the next mined block; it fails when
+// the chain uses a retarget algorithm
+func (c *Chain) SetDifficulty(difficulty int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.config.Retarget != retargetManual {
+		return fmt.Errorf("tingkat kesulitan diatur otomatis oleh algoritma retarget %s", c.config.Retarget)
+	}
+	c.difficulty = difficulty
+	return nil
+}
+
+// Append saves block to disk and adds it to the chain if it extends the current tip
+func (c *Chain) Append(block Block) error {
+	return c.appendTraced(block, nil)
+}
+
+// appendTraced is Append recording the validate, persist and broadcast steps in trace
+func (c *Chain) appendTraced(block Block, trace *blockTrace) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tip := c.blocks[len(c.blocks)-1]
+	if block.PreviousHash != tip.Hash {
+		return fmt.Errorf("blok %d tidak menyambung ke tip blockchain", block.Index)
+	}
+
+	// Blok baru harus lolos semua aturan validasi sebelum diterima
+	candidate := append(c.blocks[:len(c.blocks):len(c.blocks)], block)
+	endValidate, endTraceValidate := startSpan("validate.block"), trace.start("validate")
+	rule, err := validateBlock(c.config, candidate, len(candidate)-1)
+	endValidate()
+	if err != nil {
+		endTraceValidate("rule", rule, "error", err.Error())
+		c.events.Publish(chainEvent{Type: eventValidationFailure, Block: &block, Rule: rule, Error: err.Error()})
+		return fmt.Errorf("blok %d ditolak oleh aturan %s: %v", block.Index, rule, err)
+	}
+	endTraceValidate()
+
+	// Blok hanya ditambahkan setelah tersimpan agar memori tetap sama dengan disk
+	endPersist := trace.start("persist")
+	if err := saveBlock(c.store, block); err != nil {
+		endPersist("error", err.Error())
+		return err
+	}
+	endPersist()
+	c.blocks = append(c.blocks, block)
+	endBroadcast := trace.start("broadcast")
+	c.events.Publish(chainEvent{Type: eventBlock, Block: &block})
+	endBroadcast()
+
+	// Evidence yang sudah masuk blok tidak perlu disertakan lagi
+	slashed := slashedValidators([]Block{block})
+	pending := c.evidence[:0]
+	for _, evidence := range c.evidence {
+		if !slashed[evidence.Validator] {
+			pending = append(pending, evidence)
+		}
+	}
+	c.evidence = pending
+	return nil
+}
+
+// SubmitEvidence verifies double-sign evidence and keeps it until the next block includes it
+func (c *Chain) SubmitEvidence(evidence DoubleSignEvidence) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.config.Consensus != consensusHybrid {
+		return fmt.Errorf("slashing hanya tersedia pada konsensus %s", consensusHybrid)
+	}
+	if err := verifyEvidence(c.config.Validators, evidence); err != nil {
+		return err
+	}
+	if slashedValidators(c.blocks)[evidence.Validator] {
+		return fmt.Errorf("validator %q sudah di-slash", evidence.Validator)
+	}
+	for _, pending := range c.evidence {
+		if pending.Validator == evidence.Validator {
+			return fmt.Errorf("evidence terhadap validator %q sudah menunggu dimasukkan ke blok", evidence.Validator)
+		}
+	}
+	c.evidence = append(c.evidence, evidence)
+	return nil
+}
+
+// PendingEvidence returns a copy of the evidence waiting to be included in a block
+func (c *Chain) PendingEvidence() []DoubleSignEvidence {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]DoubleSignEvidence(nil), c.evidence...)
+}
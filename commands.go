@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// command is a non-interactive subcommand invoked as `blockchain <name> [args...]`
+type command struct {
+	usage       string
+	description string
+	run         func(store BlockStore, args []string) error // store is the block store of the chain
+}
+
+// commands lists the available subcommands by name
+var commands = map[string]command{
+	"backup": {
+		usage:       "backup <dir|s3://bucket/prefix>",
+		description: "Backup inkremental chain ke direktori lain atau object store S3-compatible",
+		run:         runBackupCommand,
+	},
+	"bench": {
+		usage:       "bench [-duration 3s] [-memory KiB]",
+		description: "Ukur hashrate mesin ini untuk setiap algoritma proof-of-work",
+		run:         runBenchCommand,
+	},
+	"conformance": {
+		usage:       "conformance [-vectors testdata/vectors.json] | conformance generate [-out file]",
+		description: "Jalankan test vector hash dan validitas blok, misalnya untuk memeriksa implementasi ulang",
+		run:         runConformanceCommand,
+	},
+	"estimate": {
+		usage:       "estimate [-difficulty D] [-hashrate H] [-within 1m]",
+		description: "Perkirakan jumlah percobaan, waktu, dan peluang menemukan blok per tingkat kesulitan",
+		run:         runEstimateCommand,
+	},
+	"experiment": {
+		usage:       "experiment orphans|retarget|bft|doublespend [opsi]",
+		description: "Jalankan eksperimen (orphan rate, osilasi difficulty, konsensus BFT, double spend)",
+		run:         runExperimentCommand,
+	},
+	"fixtures": {
+		usage:       "fixtures generate [-out testdata] [-blocks N] [-difficulty D] [-seed S] | fixtures check [-dir testdata]",
+		description: "Buat chain referensi deterministik beserta hash golden, atau periksa implementasi terhadapnya",
+		run:         runFixturesCommand,
+	},
+	"gencert": {
+		usage:       "gencert <cert.pem> <key.pem> [host...]",
+		description: "Buat sertifikat TLS self-signed untuk REST API",
+		run:         runGenCertCommand,
+	},
+	"host": {
+		usage:       "host [-addr :8080] <chains.json>",
+		description: "Jalankan beberapa chain terpisah di satu server, masing-masing di bawah prefix /<nama>/",
+		run:         runHostCommand,
+	},
+	"loadgen": {
+		usage:       "loadgen [-node url] [-rate R] [-duration 30s] [-size N] [-size-dist fixed|uniform|exp] [-senders S] [-zipf s]",
+		description: "Kirim permintaan blok dengan laju tetap ke antrian mining node dan ukur latensinya",
+		run:         runLoadgenCommand,
+	},
+	"mine": {
+		usage:       "mine [-parent hash|index] [-extra-data text] [-out file] [-validators dir] <data>",
+		description: "Mining satu blok di atas blok tertentu, misalnya untuk membuat fork dengan sengaja",
+		run:         runMineCommand,
+	},
+	"simulate": {
+		usage:       "simulate [-blocks N] [-difficulty D] [-hashrate H]",
+		description: "Simulasikan waktu penemuan blok secara statistik tanpa hashing",
+		run:         runSimulateCommand,
+	},
+	"restore": {
+		usage:       "restore [-force] <dir|s3://bucket/prefix>",
+		description: "Pulihkan chain dari backup ke -datadir",
+		run:         runRestoreCommand,
+	},
+	"rollback": {
+		usage:       "rollback [-yes] <height>",
+		description: "Potong blockchain ke tinggi tertentu; blok yang dibuang diarsipkan",
+		run:         runRollbackCommand,
+	},
+	"snapshot": {
+		usage:       "snapshot list|create [-keep K]|restore <height>",
+		description: "Kelola snapshot chain untuk rollback eksperimen",
+		run:         runSnapshotCommand,
+	},
+	"submitblock": {
+		usage:       "submitblock <file|hex|->",
+		description: "Validasi blok dari luar terhadap tip chain lokal dan tambahkan jika valid",
+		run:         runSubmitBlockCommand,
+	},
+	"tamper": {
+		usage:       "tamper [-out dir] <index> <jenis>",
+		description: "Rusak salinan sebuah blok dan tunjukkan aturan validasi yang menangkapnya",
+		run:         runTamperCommand,
+	},
+	"tutorial": {
+		usage:       "tutorial [-difficulty D] [-show N] [-delay 400ms] [-yes] [data]",
+		description: "Panduan langkah demi langkah mining satu blok latihan untuk pemula",
+		run:         runTutorialCommand,
+	},
+	"validate": {
+		usage:       "validate [-level quick|standard|paranoid|all]",
+		description: "Verifikasi blockchain lokal pada level tertentu beserta waktunya",
+		run:         runValidateCommand,
+	},
+	"validator": {
+		usage:       "validator keygen|list|delegate|rewards|doublesign [argumen]",
+		description: "Kelola key validator dan delegasi stake untuk konsensus hybrid PoW/PoS",
+		run:         runValidatorCommand,
+	},
+	"block": {
+		usage:       "block <index> [-json|-raw]",
+		description: "Tampilkan byte yang di-hash dari sebuah blok, per field dengan offset",
+		run:         runBlockCommand,
+	},
+	"db": {
+		usage:       "db stats|compact [-archive]|encrypt",
+		description: "Laporan pemakaian disk chain, pembersihan file sisa dan enkripsi ulang blok",
+		run:         runDBCommand,
+	},
+	"decode": {
+		usage:       "decode [-pow nama] [file|-]",
+		description: "Parse blok (JSON atau hex) dari file atau stdin dan periksa konsistensinya tanpa menyentuh chain lokal",
+		run:         runDecodeCommand,
+	},
+	"discover": {
+		usage:       "discover [-timeout 6s]",
+		description: "Cari node dengan REST API di jaringan lokal",
+		run:         runDiscoverCommand,
+	},
+	"doctor": {
+		usage:       "doctor [-peer url]",
+		description: "Periksa datadir, ruang disk, konfigurasi, chain, dan jam beserta saran perbaikannya",
+		run:         runDoctorCommand,
+	},
+	"epoch": {
+		usage:       "epoch info",
+		description: "Tampilkan epoch saat ini dan validator set yang berlaku",
+		run:         runEpochCommand,
+	},
+	"heal": {
+		usage:       "heal [-from url,url|auto]",
+		description: "Ganti blok lokal yang rusak dengan salinan terverifikasi dari peer",
+		run:         runHealCommand,
+	},
+	"hasher": {
+		usage:       "hasher",
+		description: "Hasher eksternal referensi: terima unit kerja JSON di stdin, kirim solusi di stdout",
+		run:         runHasherCommand,
+	},
+	"payload": {
+		usage:       "payload kv key=value... | json [-schema name] <file|-> | digest <file> | schema <name> <file> | show <index> [key]",
+		description: "Buat dan baca data blok terstruktur: record key-value, dokumen JSON dengan schema, atau digest file",
+		run:         runPayloadCommand,
+	},
+	"race": {
+		usage:       "race [-players a,b,c] [-duration 30s] [-difficulty D] [-live=false]",
+		description: "Mini-game: beberapa pemain lokal berlomba mining blok ke satu chain, dengan papan skor langsung",
+		run:         runRaceCommand,
+	},
+	"rules": {
+		usage:       "rules list",
+		description: "Tampilkan aturan validasi yang aktif",
+		run:         runRulesCommand,
+	},
+	"ancestor": {
+		usage:       "ancestor <hash|index> [n]",
+		description: "Tampilkan leluhur ke-n dari sebuah blok",
+		run:         runAncestorCommand,
+	},
+	"isancestor": {
+		usage:       "isancestor <hash|index> [hash|index]",
+		description: "Periksa apakah blok pertama leluhur blok kedua (default tip)",
+		run:         runIsAncestorCommand,
+	},
+	"attest": {
+		usage:       "attest [-out file]",
+		description: "Tandatangani tinggi dan hash tip blockchain lokal dengan key node",
+		run:         runAttestCommand,
+	},
+	"verify-attestation": {
+		usage:       "verify-attestation [-node id] [-chain] <file>",
+		description: "Periksa tanda tangan attestation dan, dengan -chain, bahwa blockchain lokal memuat blok yang di-attest",
+		run:         runVerifyAttestationCommand,
+	},
+}
+
+// runCommand executes the subcommand named by args[0]
+func runCommand(store BlockStore, args []string) error {
+	cmd, ok := commands[args[0]]
+	if !ok {
+		printCommandUsage()
+		return fmt.Errorf("perintah tidak dikenal: %q", args[0])
+	}
+	return cmd.run(store, args[1:])
+}
+
+// printCommandUsage lists every subcommand with its description
+func printCommandUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Println(BoldYellow + "Perintah yang tersedia:" + Reset)
+	for _, name := range names {
+		fmt.Printf("  %s%-30s%s %s\n", BoldCyan, commands[name].usage, Reset, commands[name].description)
+	}
+}
+
+func runRulesCommand(store BlockStore, args []string) error {
+	if len(args) != 1 || args[0] != "list" {
+		return fmt.Errorf("penggunaan: rules list")
+	}
+	printValidationRules()
+	return nil
+}
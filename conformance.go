@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// vectorsFile holds the cross-implementation test vectors next to the fixtures
+const vectorsFile = "vectors.json"
+
+// hashVector pairs the canonical bytes of a block with the hash every implementation must compute
+type hashVector struct {
+	Name      string `json:"name"`
+	PoW       string `json:"pow"`
+	MemoryKiB int    `json:"pow_memory_kib,omitempty"`
+	Record    string `json:"record"` // Hex
+	Hash      string `json:"hash"`
+}
+
+// validityVector is a chain whose last block must be accepted, or rejected by the named rule;
+// the earlier blocks are valid context
+type validityVector struct {
+	Name   string  `json:"name"`
+	Blocks []Block `json:"blocks"`
+	Valid  bool    `json:"valid"`
+	Rule   string  `json:"rule,omitempty"` // Aturan pertama yang dilanggar
+}
+
+// conformanceVectors is the content of vectors.json
+type conformanceVectors struct {
+	Genesis  GenesisConfig    `json:"genesis"` // Parameter chain untuk validity vector
+	Hashes   []hashVector     `json:"hashes"`
+	Validity []validityVector `json:"validity"`
+}
+
+// buildConformanceVectors derives the vectors from the deterministic fixture chain
+func buildConformanceVectors() conformanceVectors {
+	cfg := fixtureGenesisConfig()
+	const difficulty = 3
+	chain := fixtureChain(cfg, 1, 3, difficulty)
+	vectors := conformanceVectors{Genesis: cfg}
+
+	for _, block := range chain {
+		vectors.Hashes = append(vectors.Hashes, hashVector{Name: fmt.Sprintf("sha256 blok %d", block.Index), PoW: powSHA256, Record: hex.EncodeToString(blockRecord(block)), Hash: block.Hash})
+	}
+	empty := powHash(GenesisConfig{PoW: powSHA256}, nil)
+	vectors.Hashes = append(vectors.Hashes, hashVector{Name: "sha256 record kosong", PoW: powSHA256, Record: "", Hash: hex.EncodeToString(empty[:])})
+	memhard := GenesisConfig{PoW: powMemHard, PoWMemoryKiB: 64}
+	memhardHash := powHash(memhard, blockRecord(chain[1]))
+	vectors.Hashes = append(vectors.Hashes, hashVector{Name: "memhard 64 KiB blok 1", PoW: powMemHard, MemoryKiB: 64, Record: hex.EncodeToString(blockRecord(chain[1])), Hash: hex.EncodeToString(memhardHash[:])})
+
+	// Setiap kasus invalid hanya melanggar satu aturan; blok di-mining ulang bila perlu agar
+	// aturan yang lebih awal tetap terpenuhi
+	last := chain[2]
+	remine := func(timestamp time.Time, previousHash string) Block {
+		return mineFixtureBlock(cfg, last.Index, timestamp, last.Data, previousHash, difficulty)
+	}
+	lastTime, _ := time.Parse(time.RFC3339, last.Timestamp)
+	variant := func(name, rule string, block Block) {
+		blocks := append(append([]Block(nil), chain[:2]...), block)
+		vectors.Validity = append(vectors.Validity, validityVector{Name: name, Blocks: blocks, Valid: rule == "", Rule: rule})
+	}
+	variant("chain valid", "", last)
+
+	changed := last
+	changed.Data += " (diubah)"
+	variant("data diubah tanpa mining ulang", "hash", changed)
+
+	variant("previous hash salah", "hash-link", remine(lastTime, genesisPreviousHash))
+
+	claimed := last
+	claimed.Difficulty = 40
+	variant("difficulty diklaim lebih tinggi dari hash", "difficulty", claimed)
+
+	variant("timestamp jauh di masa depan", "timestamp", remine(time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC), last.PreviousHash))
+	variant("timestamp tidak melewati median time past", "timestamp", remine(fixtureEpoch.Add(-time.Minute), last.PreviousHash))
+
+	genesis := chain[0]
+	genesis.PreviousHash = last.Hash
+	vectors.Validity = append(vectors.Validity, validityVector{Name: "genesis dengan previous hash bukan nol", Blocks: []Block{mineFixtureBlock(cfg, 0, fixtureEpoch, genesis.Data, genesis.PreviousHash, difficulty)}, Rule: "hash-link"})
+	return vectors
+}
+
+// runConformanceCommand implements "conformance [-vectors file]" and "conformance generate [-out file]"
+func runConformanceCommand(store BlockStore, args []string) error {
+	if len(args) > 0 && args[0] == "generate" {
+		fs := flag.NewFlagSet("conformance generate", flag.ContinueOnError)
+		out := fs.String("out", filepath.Join("testdata", vectorsFile), "File tujuan test vector")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(buildConformanceVectors(), "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*out, append(data, '\n'), 0644); err != nil {
+			return err
+		}
+		fmt.Printf(Green+"Test vector ditulis ke %s.\n"+Reset, *out)
+		return nil
+	}
+
+	fs := flag.NewFlagSet("conformance", flag.ContinueOnError)
+	path := fs.String("vectors", filepath.Join("testdata", vectorsFile), "File test vector")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(*path)
+	if err != nil {
+		return err
+	}
+	var vectors conformanceVectors
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		return fmt.Errorf("%s: %w", *path, err)
+	}
+
+	failures := 0
+	report := func(ok bool, name, detail string) {
+		if ok {
+			fmt.Printf(Green+"[OK]    %s\n"+Reset, name)
+			return
+		}
+		failures++
+		fmt.Printf(Red+"[FAIL]  %s: %s\n"+Reset, name, detail)
+	}
+
+	fmt.Println(BoldYellow + "\n=== Hash ===" + Reset)
+	for _, vector := range vectors.Hashes {
+		record, err := hex.DecodeString(vector.Record)
+		if err != nil {
+			report(false, vector.Name, "record bukan hex")
+			continue
+		}
+		sum := powHash(GenesisConfig{PoW: vector.PoW, PoWMemoryKiB: vector.MemoryKiB}, record)
+		hash := hex.EncodeToString(sum[:])
+		report(hash == vector.Hash, vector.Name, fmt.Sprintf("hash %s, diharapkan %s", hash, vector.Hash))
+	}
+
+	fmt.Println(BoldYellow + "\n=== Validitas ===" + Reset)
+	for _, vector := range vectors.Validity {
+		if len(vector.Blocks) == 0 {
+			report(false, vector.Name, "tidak ada blok")
+			continue
+		}
+		rule, err := validateBlock(vectors.Genesis, vector.Blocks, len(vector.Blocks)-1)
+		switch {
+		case vector.Valid:
+			report(err == nil, vector.Name, fmt.Sprintf("ditolak oleh aturan %s: %v", rule, err))
+		case err == nil:
+			report(false, vector.Name, fmt.Sprintf("diterima, seharusnya ditolak oleh aturan %s", vector.Rule))
+		default:
+			report(rule == vector.Rule, vector.Name, fmt.Sprintf("ditolak oleh aturan %s, seharusnya %s", rule, vector.Rule))
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d dari %d test vector gagal", failures, len(vectors.Hashes)+len(vectors.Validity))
+	}
+	fmt.Printf(Green+"\nSemua %d test vector lolos.\n"+Reset, len(vectors.Hashes)+len(vectors.Validity))
+	return nil
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Mesin konsensus yang dapat dipilih di genesis.json
+const (
+	consensusPoW    = "pow"
+	consensusHybrid = "hybrid" // PoW mengusulkan blok, validator ber-stake memfinalisasinya
+)
+
+// Validator is a staked finality signer listed in genesis.json
+type Validator struct {
+	Name      string `json:"name"`
+	PublicKey string `json:"public_key"` // Public key ed25519 dalam hex
+	Stake     uint64 `json:"stake"`
+
+	Commission  float64      `json:"commission,omitempty"`  // Bagian reward delegator yang diambil validator (0-1)
+	Delegations []Delegation `json:"delegations,omitempty"` // Stake yang didelegasikan ke validator ini
+}
+
+// ValidatorSignature is a validator's finality signature over a block hash
+type ValidatorSignature struct {
+	Validator string `json:"validator"`
+	Signature string `json:"signature"`
+}
+
+// validatorKeyFile is the on-disk format of a local validator key (<dir>/<name>.key)
+type validatorKeyFile struct {
+	PrivateKey string  `json:"private_key"` // Seed ed25519 dalam hex
+	Stake      uint64  `json:"stake"`
+	Commission float64 `json:"commission,omitempty"`
+}
+
+// finalityMessage is the byte string validators sign to finalize a block
+func finalityMessage(block Block) []byte {
+	return []byte("finalize:" + strconv.Itoa(block.Index) + ":" + block.Hash)
+}
+
+// totalStake returns the voting power (own plus delegated stake) of the whole validator set
+func totalStake(validators []Validator) uint64 {
+	var total uint64
+	for _, validator := range validators {
+		total += validator.power()
+	}
+	return total
+}
+
+// signedStake verifies the finality signatures of block and returns the voting power behind them
+func signedStake(validators []Validator, block Block) (uint64, error) {
+	byName := make(map[string]Validator, len(validators))
+	for _, validator := range validators {
+		byName[validator.Name] = validator
+	}
+
+	var stake uint64
+	seen := make(map[string]bool)
+	for _, sig := range block.Signatures {
+		validator, ok := byName[sig.Validator]
+		if !ok {
+			return 0, fmt.Errorf("unknown validator %q", sig.Validator)
+		}
+		if seen[sig.Validator] {
+			return 0, fmt.Errorf("duplicate signature from validator %q", sig.Validator)
+		}
+		seen[sig.Validator] = true
+
+		publicKey, err := hex.DecodeString(validator.PublicKey)
+		if err != nil || len(publicKey) != ed25519.PublicKeySize {
+			return 0, fmt.Errorf("invalid public key for validator %q", sig.Validator)
+		}
+		signature, err := hex.DecodeString(sig.Signature)
+		if err != nil || !ed25519.Verify(publicKey, finalityMessage(block), signature) {
+			return 0, fmt.Errorf("invalid signature from validator %q", sig.Validator)
+		}
+		stake += validator.power()
+	}
+	return stake, nil
+}
+
+// hasFinality reports whether signed stake is strictly more than two thirds of total stake
+func hasFinality(signed, total uint64) bool {
+	return signed*3 > total*2
+}
+
+// checkFinality requires hybrid-consensus blocks to carry signatures from more than 2/3 of the stake
+func checkFinality(cfg GenesisConfig, blockchain []Block, i int) error {
+	block := blockchain[i]
+	if cfg.Consensus != consensusHybrid {
+		if len(block.Signatures) > 0 {
+			return fmt.Errorf("Block %d carries validator signatures on a %s chain", block.Index, consensusPoW)
+		}
+		return nil
+	}
+
+	// Stake validator yang sudah di-slash sebelum epoch blok ini tidak dihitung lagi
+	validators := validatorSetAt(cfg, blockchain, i)
+	signed, err := signedStake(validators, block)
+	if err != nil {
+		return fmt.Errorf("Block %d: %v", block.Index, err)
+	}
+	total := totalStake(validators)
+	if !hasFinality(signed, total) {
+		return fmt.Errorf("Block %d is finalized by stake %d of %d, more than 2/3 is required", block.Index, signed, total)
+	}
+	return nil
+}
+
+// finalizeBlock adds signatures from every key in keys, by validator name, that belongs to the validator set
+func finalizeBlock(cfg GenesisConfig, keys map[string]ed25519.PrivateKey, block *Block) {
+	if cfg.Consensus != consensusHybrid {
+		return
+	}
+	for _, validator := range cfg.Validators {
+		key, ok := keys[validator.Name]
+		if !ok || hex.EncodeToString(key.Public().(ed25519.PublicKey)) != validator.PublicKey {
+			continue
+		}
+		signature := ed25519.Sign(key, finalityMessage(*block))
+		block.Signatures = append(block.Signatures, ValidatorSignature{Validator: validator.Name, Signature: hex.EncodeToString(signature)})
+	}
+}
+
+// loadValidatorKeys reads every <name>.key file in dir and returns the keys and their validator entries
+func loadValidatorKeys(dir string) (map[string]ed25519.PrivateKey, []Validator, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.key"))
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(files)
+
+	keys := make(map[string]ed25519.PrivateKey)
+	var validators []Validator
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		var keyFile validatorKeyFile
+		if err := json.Unmarshal(data, &keyFile); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", file, err)
+		}
+		seed, err := hex.DecodeString(keyFile.PrivateKey)
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, nil, fmt.Errorf("%s: private_key harus berupa seed ed25519 %d byte dalam hex", file, ed25519.SeedSize)
+		}
+
+		name := strings.TrimSuffix(filepath.Base(file), ".key")
+		key := ed25519.NewKeyFromSeed(seed)
+		keys[name] = key
+		validators = append(validators, Validator{
+			Name:       name,
+			PublicKey:  hex.EncodeToString(key.Public().(ed25519.PublicKey)),
+			Stake:      keyFile.Stake,
+			Commission: keyFile.Commission,
+		})
+	}
+
+	delegations, err := loadDelegations(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := attachDelegations(validators, delegations); err != nil {
+		return nil, nil, err
+	}
+	return keys, validators, nil
+}
+
+func runValidatorCommand(store BlockStore, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("penggunaan: validator keygen <dir> <nama> <stake> [komisi%%] | list | delegate [-validators dir|<dir>] <delegator> <validator> <jumlah> | rewards | doublesign <dir> <nama> <index>")
+	}
+
+	switch args[0] {
+	case "keygen":
+		if len(args) != 4 && len(args) != 5 {
+			return fmt.Errorf("penggunaan: validator keygen <dir> <nama> <stake> [komisi%%]")
+		}
+		stake, err := strconv.ParseUint(args[3], 10, 64)
+		if err != nil || stake == 0 {
+			return fmt.Errorf("stake harus berupa angka positif")
+		}
+		var commission float64
+		if len(args) == 5 {
+			percent, err := strconv.ParseFloat(args[4], 64)
+			if err != nil || percent < 0 || percent > 100 {
+				return fmt.Errorf("komisi harus berupa persentase antara 0 dan 100")
+			}
+			commission = percent / 100
+		}
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(validatorKeyFile{PrivateKey: hex.EncodeToString(key.Seed()), Stake: stake, Commission: commission}, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(args[1], 0700); err != nil {
+			return err
+		}
+		path := filepath.Join(args[1], args[2]+".key")
+		if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+			return err
+		}
+		fmt.Printf(Green+"Key validator %s (stake %d) disimpan di %s.\n"+Reset, args[2], stake, path)
+		fmt.Printf("%sPublic key:%s %s\n", BoldCyan, Reset, hex.EncodeToString(key.Public().(ed25519.PublicKey)))
+		return nil
+
+	case "list":
+		cfg, blocks, err := loadChain(store)
+		if err != nil {
+			return err
+		}
+		// Delegasi yang di-mine ikut ditampilkan
+		slashed := slashedValidators(blocks)
+		fmt.Printf(BoldYellow+"\n=== Validator (konsensus %s) ===\n"+Reset, cfg.Consensus)
+		validators := activeValidators(cfg.Validators, blocks)
+		for i := range validators {
+			if slashed[validators[i].Name] {
+				validators[i] = cfg.Validators[i]
+			}
+		}
+		total := totalStake(validators)
+		for _, validator := range validators {
+			status := ""
+			if slashed[validator.Name] {
+				status = Red + " [di-slash]" + Reset
+			}
+			fmt.Printf("%s%-12s%s stake %-8d power %-8d (%5.1f%%) komisi %4.1f%% %s%s\n", BoldCyan, validator.Name, Reset, validator.Stake, validator.power(), float64(validator.power())*100/float64(total), validator.Commission*100, validator.PublicKey, status)
+			for _, delegation := range validator.Delegations {
+				fmt.Printf("    didelegasikan oleh %-12s %d\n", delegation.Delegator, delegation.Amount)
+			}
+		}
+		return nil
+
+	case "delegate":
+		return runDelegateCommand(store, args[1:])
+
+	case "rewards":
+		return runRewardsCommand(store, args[1:])
+
+	case "doublesign":
+		return runDoubleSignCommand(store, args[1:])
+
+	default:
+		return fmt.Errorf("subperintah validator tidak dikenal: %q", args[0])
+	}
+}
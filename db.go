@@ -0,0 +1,212 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// storageCategory is one line of the db stats report
+type storageCategory struct {
+	Name  string
+	Files int
+	Bytes int64
+}
+
+// formatBytes renders a size with a binary unit
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1f GiB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+// categorizeStoreFile names the category a file of the chain directory belongs to
+func categorizeStoreFile(rel string) string {
+	base := filepath.Base(rel)
+	switch {
+	case strings.HasSuffix(base, ".tmp") || strings.Contains(rel, ".tmp"+string(filepath.Separator)):
+		return "sementara"
+	case strings.HasPrefix(rel, snapshotsDir+string(filepath.Separator)):
+		return "snapshot"
+	case strings.HasPrefix(rel, archiveDir+string(filepath.Separator)):
+		return "arsip rollback"
+	case !strings.Contains(rel, string(filepath.Separator)) && strings.HasPrefix(base, "block") && strings.HasSuffix(base, ".json"):
+		return "blok"
+	case base == genesisConfigFile || base == nodeKeyFile || base == miningStateFile || base == storageKeyFile:
+		return "metadata"
+	case !strings.Contains(rel, string(filepath.Separator)) && isSchemaFile(base):
+		return "schema"
+	default:
+		return "lainnya"
+	}
+}
+
+// storageStats walks dir and sums the files per category
+func storageStats(dir string) ([]storageCategory, error) {
+	order := []string{"blok", "metadata", "schema", "snapshot", "arsip rollback", "sementara", "lainnya"}
+	totals := make(map[string]*storageCategory)
+	for _, name := range order {
+		totals[name] = &storageCategory{Name: name}
+	}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		category := totals[categorizeStoreFile(rel)]
+		category.Files++
+		category.Bytes += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]storageCategory, 0, len(order))
+	for _, name := range order {
+		stats = append(stats, *totals[name])
+	}
+	return stats, nil
+}
+
+// Compact removes the temporary files left by interrupted writes and, if archive is set,
+// the blocks archived by rollback; it returns the number of bytes reclaimed
+func (s fileBlockStore) Compact(archive bool) (int64, error) {
+	var reclaimed int64
+	var targets []string
+	err := filepath.WalkDir(s.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(s.dir, path)
+		if strings.HasSuffix(entry.Name(), ".tmp") || (archive && rel == archiveDir) {
+			targets = append(targets, path)
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, target := range targets {
+		filepath.WalkDir(target, func(path string, entry fs.DirEntry, err error) error {
+			if err == nil && !entry.IsDir() {
+				if info, err := entry.Info(); err == nil {
+					reclaimed += info.Size()
+				}
+			}
+			return nil
+		})
+		if err := os.RemoveAll(target); err != nil {
+			return reclaimed, err
+		}
+	}
+	return reclaimed, nil
+}
+
+// Encrypt rewrites every block with the storage cipher of s, including blocks stored as
+// plaintext; it returns the number of blocks
+func (s fileBlockStore) Encrypt() (int, error) {
+	if s.cipher == nil {
+		return 0, fmt.Errorf("storage key belum diatur: gunakan -storage-key-file atau BLOCKCHAIN_STORAGE_KEY")
+	}
+	lenient := *s.cipher
+	lenient.acceptPlaintext = true
+	source := s
+	source.cipher = &lenient
+	blocks, err := source.LoadBlocks()
+	if err != nil {
+		return 0, err
+	}
+	for _, block := range blocks {
+		if err := s.SaveBlock(block); err != nil {
+			return 0, err
+		}
+	}
+	return len(blocks), nil
+}
+
+// runDBCommand implements "db stats", "db compact [-archive]" and "db encrypt"
+func runDBCommand(store BlockStore, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("penggunaan: db stats|compact [-archive]|encrypt")
+	}
+
+	switch args[0] {
+	case "stats":
+		// Statistik juga tersedia untuk chain yang dibuka read-only
+		disk, ok := onDiskStore(store)
+		if !ok {
+			return fmt.Errorf("db stats hanya tersedia untuk chain di disk")
+		}
+		stats, err := storageStats(disk.dir)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%sPenyimpanan %s%s\n", BoldCyan, disk.dir, Reset)
+		var files int
+		var total int64
+		for _, category := range stats {
+			fmt.Printf("  %-15s %6d file  %10s\n", category.Name, category.Files, formatBytes(category.Bytes))
+			files += category.Files
+			total += category.Bytes
+		}
+		fmt.Printf("  %-15s %6d file  %10s\n", "total", files, formatBytes(total))
+		if blocks := stats[0]; blocks.Files > 0 {
+			fmt.Printf("Rata-rata per blok: %s\n", formatBytes(blocks.Bytes/int64(blocks.Files)))
+		}
+		fmt.Println("Chain ini tidak memiliki indeks atau state terpisah; semuanya dibangun ulang dari file blok.")
+		return nil
+
+	case "compact":
+		fs := flag.NewFlagSet("db compact", flag.ContinueOnError)
+		archive := fs.Bool("archive", false, "Hapus juga blok yang diarsipkan oleh rollback")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		files, ok := store.(fileBlockStore)
+		if !ok {
+			return fmt.Errorf("db compact hanya tersedia untuk chain di disk yang dapat ditulis")
+		}
+		reclaimed, err := files.Compact(*archive)
+		if err != nil {
+			return err
+		}
+		fmt.Printf(Green+"%s dibebaskan.\n"+Reset, formatBytes(reclaimed))
+		return nil
+
+	case "encrypt":
+		files, ok := store.(fileBlockStore)
+		if !ok {
+			return fmt.Errorf("db encrypt hanya tersedia untuk chain di disk yang dapat ditulis")
+		}
+		count, err := files.Encrypt()
+		if err != nil {
+			return err
+		}
+		fmt.Printf(Green+"%d blok ditulis ulang dengan enkripsi.\n"+Reset, count)
+		return nil
+
+	default:
+		return fmt.Errorf("perintah db tidak dikenal: %q", args[0])
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// readBlockBlob reads a block given as JSON, hex-encoded JSON, or the output of "block -json"
+func readBlockBlob(data []byte) (Block, error) {
+	text := strings.TrimSpace(string(data))
+	if raw, err := hex.DecodeString(text); err == nil && len(raw) > 0 {
+		text = strings.TrimSpace(string(raw))
+	}
+
+	// Output "block -json" dan GET /blocks/{index}/raw membungkus blok di field "block"
+	var wrapper struct {
+		Block json.RawMessage `json:"block"`
+	}
+	if json.Unmarshal([]byte(text), &wrapper) == nil && len(wrapper.Block) > 0 && wrapper.Block[0] == '{' {
+		text = string(wrapper.Block)
+	}
+	return decodeBlock([]byte(text))
+}
+
+// decodeCheck is the outcome of one consistency check of a decoded block
+type decodeCheck struct {
+	name string
+	err  error
+}
+
+// checkDecodedBlock verifies what can be checked from the block alone, given the genesis config
+// cfg of its chain: hash, difficulty, finality signatures and slashing evidence
+func checkDecodedBlock(cfg GenesisConfig, block Block) []decodeCheck {
+	single := []Block{block}
+	checks := []decodeCheck{
+		{"hash", checkBlockHash(cfg, single, 0)},
+		{"difficulty", checkDifficulty(cfg, single, 0)},
+	}
+
+	if cfg.Consensus == consensusHybrid {
+		signed, err := signedStake(cfg.Validators, block)
+		if err == nil && !hasFinality(signed, totalStake(cfg.Validators)) {
+			err = fmt.Errorf("signatures carry stake %d of %d, more than 2/3 is required", signed, totalStake(cfg.Validators))
+		}
+		checks = append(checks, decodeCheck{"signatures", err})
+	} else if len(block.Signatures) > 0 {
+		checks = append(checks, decodeCheck{"signatures", fmt.Errorf("block carries validator signatures but the chain uses %s consensus", cfg.Consensus)})
+	}
+
+	for _, evidence := range block.Evidence {
+		checks = append(checks, decodeCheck{"evidence " + evidence.Validator, verifyEvidence(cfg.Validators, evidence)})
+	}
+	return checks
+}
+
+// runDecodeCommand implements "decode [file]": parse a block from a file or stdin and check it
+// without loading or changing the local chain
+func runDecodeCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("decode", flag.ContinueOnError)
+	powName := fs.String("pow", "", "Algoritma proof-of-work untuk memeriksa hash (default dari genesis.json lokal)")
+	powMemory := fs.Int("pow-memory", 0, "Ukuran scratchpad PoW memhard dalam KiB (default dari genesis.json lokal)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var data []byte
+	var err error
+	if fs.NArg() == 0 || fs.Arg(0) == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(fs.Arg(0))
+	}
+	if err != nil {
+		return err
+	}
+
+	// Parameter PoW dan validator set diambil dari genesis.json lokal, tanpa membaca blok
+	cfg, err := loadGenesisConfig(store)
+	if err != nil {
+		return err
+	}
+	if *powName != "" {
+		cfg.PoW = *powName
+	}
+	if *powMemory > 0 {
+		cfg.PoWMemoryKiB = *powMemory
+	}
+	if _, ok := powAlgorithms[cfg.PoW]; !ok {
+		return fmt.Errorf("algoritma proof-of-work tidak dikenal: %q", cfg.PoW)
+	}
+
+	block, err := readBlockBlob(data)
+	if err != nil {
+		return fmt.Errorf("input bukan blok yang valid: %w", err)
+	}
+	displayBlockchain(cfg, []Block{block})
+
+	failed := false
+	fmt.Println(BoldYellow + "\n=== Pemeriksaan ===" + Reset)
+	for _, check := range checkDecodedBlock(cfg, block) {
+		if check.err != nil {
+			failed = true
+			fmt.Printf(Red+"[GAGAL] %-12s %v\n"+Reset, check.name, check.err)
+		} else {
+			fmt.Printf(Green+"[OK]    %s\n"+Reset, check.name)
+		}
+	}
+	if failed {
+		return fmt.Errorf("blok tidak konsisten")
+	}
+	return nil
+}
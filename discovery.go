@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// discoveryPort is the UDP port nodes broadcast their beacons to on the local network
+const discoveryPort = 18444
+
+// discoveryInterval is how often a node announces itself
+const discoveryInterval = 5 * time.Second
+
+// discoveryService identifies beacons of this simulator among other UDP traffic
+const discoveryService = "blockchain-simulation"
+
+// discoveryBeacon is the UDP broadcast a node sends so others on the LAN can find its REST API
+type discoveryBeacon struct {
+	Service   string `json:"service"`
+	NodeID    string `json:"node_id"`
+	PublicKey string `json:"public_key"`
+	APIPort   int    `json:"api_port"`
+	TLS       bool   `json:"tls"`
+	Genesis   string `json:"genesis"` // Hash blok genesis, untuk membedakan chain
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Signature string `json:"signature,omitempty"` // Tanda tangan node key atas beacon tanpa field ini
+}
+
+// maxBeaconAge is how old a beacon may be before it is treated as a replay
+const maxBeaconAge = time.Minute
+
+// signingBytes returns the bytes covered by the beacon signature
+func (b discoveryBeacon) signingBytes() []byte {
+	b.Signature = ""
+	data, _ := json.Marshal(b)
+	return data
+}
+
+// discoveredNode is a node found through its beacon, identified by its node ID
+type discoveredNode struct {
+	ID       string
+	URL      string
+	Genesis  string
+	Height   int
+	LastSeen time.Time
+}
+
+// announce broadcasts a signed beacon for the API at apiAddr every discoveryInterval until ctx is cancelled
+func announce(ctx context.Context, chain *Chain, identity *nodeIdentity, apiAddr string, tls bool) error {
+	_, portText, err := net.SplitHostPort(apiAddr)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil {
+		return fmt.Errorf("port API tidak valid: %q", portText)
+	}
+	conn, err := net.DialUDP("udp4", nil, &net.UDPAddr{IP: net.IPv4bcast, Port: discoveryPort})
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer conn.Close()
+		for {
+			beacon := discoveryBeacon{
+				Service:   discoveryService,
+				NodeID:    identity.ID,
+				PublicKey: identity.PublicKey(),
+				APIPort:   port,
+				TLS:       tls,
+				Genesis:   chain.Blocks()[0].Hash,
+				Height:    chain.Len() - 1,
+				Timestamp: time.Now().Unix(),
+			}
+			beacon.Signature = identity.Sign(beacon.signingBytes())
+			if data, err := json.Marshal(beacon); err == nil {
+				// Kegagalan broadcast (misalnya tanpa jaringan) diabaikan dan dicoba lagi nanti
+				conn.Write(data)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(discoveryInterval):
+			}
+		}
+	}()
+	return nil
+}
+
+// discoverNodes listens for beacons for the given duration and returns the nodes found; beacons
+// that are not signed by the key of their node ID are ignored, so an address cannot claim
+// another node's identity
+func discoverNodes(timeout time.Duration) ([]discoveredNode, error) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{Port: discoveryPort})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	nodes := make(map[string]discoveredNode)
+	deadline := time.Now().Add(timeout)
+	conn.SetReadDeadline(deadline)
+	buf := make([]byte, 1024)
+	for time.Now().Before(deadline) {
+		n, from, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			break // Deadline tercapai
+		}
+		var beacon discoveryBeacon
+		if json.Unmarshal(buf[:n], &beacon) != nil || beacon.Service != discoveryService {
+			continue
+		}
+		if age := time.Since(time.Unix(beacon.Timestamp, 0)); age > maxBeaconAge || age < -maxBeaconAge {
+			continue
+		}
+		if verifyNodeSignature(beacon.NodeID, beacon.PublicKey, beacon.signingBytes(), beacon.Signature) != nil {
+			continue
+		}
+		scheme := "http"
+		if beacon.TLS {
+			scheme = "https"
+		}
+		url := scheme + "://" + net.JoinHostPort(from.IP.String(), strconv.Itoa(beacon.APIPort))
+		nodes[beacon.NodeID] = discoveredNode{ID: beacon.NodeID, URL: url, Genesis: beacon.Genesis, Height: beacon.Height, LastSeen: time.Now()}
+	}
+
+	found := make([]discoveredNode, 0, len(nodes))
+	for _, node := range nodes {
+		found = append(found, node)
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].ID < found[j].ID })
+	return found, nil
+}
+
+func runDiscoverCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("discover", flag.ContinueOnError)
+	timeout := fs.Duration("timeout", discoveryInterval+time.Second, "Lama mendengarkan beacon node")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	fmt.Printf(BoldYellow+"Mencari node di jaringan lokal selama %s...\n"+Reset, *timeout)
+	nodes, err := discoverNodes(*timeout)
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		fmt.Println(Yellow + "Tidak ada node yang ditemukan." + Reset)
+		return nil
+	}
+	for _, node := range nodes {
+		fmt.Printf("%s%s%s %-28s tinggi %-6d genesis %.16s...\n", BoldCyan, node.ID, Reset, node.URL, node.Height, node.Genesis)
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+//go:build !(linux || darwin || freebsd)
+
+package main
+
+import "errors"
+
+// freeDiskSpace is not implemented on this platform
+func freeDiskSpace(dir string) (uint64, error) {
+	return 0, errors.New("tidak didukung di platform ini")
+}
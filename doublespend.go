@@ -0,0 +1,142 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// maxDoubleSpendDeficit is how many blocks an attacker may fall behind before the attempt is
+// counted as failed; catching up from there has a negligible chance when q < 0.5
+const maxDoubleSpendDeficit = 60
+
+// doubleSpendResult is the outcome of one attacker share / confirmation count combination
+type doubleSpendResult struct {
+	Share         float64 // Bagian hashrate attacker (q)
+	Confirmations int     // Konfirmasi yang ditunggu merchant (z)
+	Trials        int
+	Successes     int
+	Predicted     float64 // Peluang berhasil menurut whitepaper Bitcoin
+}
+
+// simulateDoubleSpend races an attacker with hashrate share q against the honest network. The
+// merchant ships after the payment has z confirmations; meanwhile the attacker mines a secret
+// chain with the conflicting spend and wins if it ever catches up with the honest chain.
+func simulateDoubleSpend(rng *rand.Rand, q float64, z, trials int) int {
+	successes := 0
+	for trial := 0; trial < trials; trial++ {
+		honest, attacker := 0, 0
+		// Setiap blok berikutnya ditemukan attacker dengan peluang q
+		for honest < z {
+			if rng.Float64() < q {
+				attacker++
+			} else {
+				honest++
+			}
+		}
+		for attacker < honest && honest-attacker <= maxDoubleSpendDeficit {
+			if rng.Float64() < q {
+				attacker++
+			} else {
+				honest++
+			}
+		}
+		if attacker >= honest {
+			successes++
+		}
+	}
+	return successes
+}
+
+// nakamotoProbability is the attacker success probability from section 11 of the Bitcoin
+// whitepaper: the attacker's progress is Poisson with mean z*q/p, and from k blocks behind it
+// catches up with probability (q/p)^k
+func nakamotoProbability(q float64, z int) float64 {
+	p := 1 - q
+	if q >= p {
+		return 1
+	}
+	lambda := float64(z) * q / p
+	sum := 1.0
+	for k := 0; k <= z; k++ {
+		poisson := math.Exp(-lambda)
+		for i := 1; i <= k; i++ {
+			poisson *= lambda / float64(i)
+		}
+		sum -= poisson * (1 - math.Pow(q/p, float64(z-k)))
+	}
+	return sum
+}
+
+// runDoubleSpendExperiment implements "experiment doublespend": a merchant accepting a payment
+// with 0, 1, 3, ... confirmations against attackers of several sizes, with a written report
+func runDoubleSpendExperiment(args []string) error {
+	fs := flag.NewFlagSet("experiment doublespend", flag.ContinueOnError)
+	shares := fs.String("shares", "0.1,0.25,0.4", "Daftar bagian hashrate attacker (0 < q < 1)")
+	confirmations := fs.String("confirmations", "0,1,3,6", "Daftar jumlah konfirmasi yang ditunggu merchant")
+	trials := fs.Int("trials", 20000, "Jumlah percobaan serangan per kombinasi")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "Seed generator acak")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	shareList, err := parseFloatList(*shares)
+	if err != nil {
+		return err
+	}
+	confirmationList, err := parseFloatList(*confirmations)
+	if err != nil {
+		return err
+	}
+	if *trials < 1 {
+		return fmt.Errorf("trials harus >= 1")
+	}
+
+	rng := rand.New(rand.NewSource(*seed))
+	var results []doubleSpendResult
+	for _, q := range shareList {
+		if q <= 0 || q >= 1 {
+			return fmt.Errorf("bagian hashrate attacker harus antara 0 dan 1")
+		}
+		for _, c := range confirmationList {
+			z := int(c)
+			if z < 0 || float64(z) != c {
+				return fmt.Errorf("jumlah konfirmasi harus bilangan bulat >= 0")
+			}
+			results = append(results, doubleSpendResult{Share: q, Confirmations: z, Trials: *trials, Successes: simulateDoubleSpend(rng, q, z, *trials), Predicted: nakamotoProbability(q, z)})
+		}
+	}
+
+	fmt.Println(BoldYellow + "\n=== Lab Double Spend ===" + Reset)
+	fmt.Println("Merchant mengirim barang setelah pembayaran mendapat z konfirmasi. Attacker diam-diam")
+	fmt.Println("menambang chain dengan transaksi yang membelanjakan koin yang sama dan menang jika chain")
+	fmt.Println("tersebut menyusul chain jujur.")
+	fmt.Printf("%d percobaan per kombinasi, seed %d\n", *trials, *seed)
+	fmt.Printf("%s%8s %8s %12s %12s%s\n", BoldCyan, "Attacker", "Konfirm.", "Simulasi", "Whitepaper", Reset)
+	for _, result := range results {
+		rate := float64(result.Successes) / float64(result.Trials)
+		color := Green
+		if rate >= 0.01 {
+			color = Red
+		}
+		fmt.Printf("%7.0f%% %8d %s%11.3f%%%s %11.3f%%\n", result.Share*100, result.Confirmations, color, rate*100, Reset, result.Predicted*100)
+	}
+
+	// Laporan singkat: konfirmasi minimum agar peluang serangan di bawah 0,1%
+	fmt.Println(BoldYellow + "\nKesimpulan:" + Reset)
+	fmt.Println("- Dengan 0 konfirmasi, attacker cukup menyiarkan transaksi tandingan: serangan selalu berhasil.")
+	for _, q := range shareList {
+		z := 0
+		for nakamotoProbability(q, z) >= 0.001 && z < 200 {
+			z++
+		}
+		if z == 200 {
+			fmt.Printf("- Attacker %.0f%%: tidak ada jumlah konfirmasi yang aman.\n", q*100)
+			continue
+		}
+		fmt.Printf("- Attacker %.0f%%: merchant perlu menunggu %d konfirmasi agar peluang serangan < 0,1%%.\n", q*100, z)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// encryptedRecordMagic prefixes every block file written with storage encryption
+var encryptedRecordMagic = []byte("BCENC1")
+
+// encryptedRecordOverhead is how many bytes encryption adds to a record: magic, nonce and GCM tag
+const encryptedRecordOverhead = 6 + 12 + 16
+
+// storageKeyFile holds the key derivation parameters of the store, next to genesis.json
+const storageKeyFile = "storage-key.json"
+
+// Parameter PBKDF2 untuk store baru, sesuai rekomendasi OWASP untuk HMAC-SHA256
+const (
+	storageKDF           = "pbkdf2-sha256"
+	storageKDFIterations = 600000
+	storageSaltSize      = 16
+)
+
+// errStorageKeyRequired is returned when an encrypted block is read without a key
+var errStorageKeyRequired = errors.New("blok terenkripsi: gunakan -storage-key-file atau BLOCKCHAIN_STORAGE_KEY")
+
+// errPlaintextRecord is returned when a key is configured but a block was stored unencrypted,
+// which would let anyone with write access to the directory slip in blocks
+var errPlaintextRecord = errors.New("blok tidak terenkripsi padahal storage key diatur; jalankan db encrypt untuk mengenkripsi chain lama")
+
+// storageKeyParams is the content of storage-key.json; the salt is not secret
+type storageKeyParams struct {
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       string `json:"salt"` // Hex
+}
+
+// storageCipher encrypts block files at rest with a key derived from the passphrase and the
+// salt of the store
+type storageCipher struct {
+	passphrase string
+	aead       cipher.AEAD // nil jika store belum memiliki salt (misalnya dibuka read-only)
+
+	acceptPlaintext bool // Hanya untuk db encrypt: blok lama tanpa enkripsi boleh dibaca
+}
+
+// pbkdf2SHA256 derives a keyLen-byte key from password and salt with PBKDF2-HMAC-SHA256 (RFC 8018)
+func pbkdf2SHA256(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	var key []byte
+	for block := uint32(1); len(key) < keyLen; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(binary.BigEndian.AppendUint32(nil, block))
+		u := prf.Sum(nil)
+		t := append([]byte(nil), u...)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLen]
+}
+
+// newGCM returns AES-256-GCM with key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// newStorageCipher derives the storage cipher of files from a user-supplied passphrase. The
+// salt and iteration count are read from storage-key.json; when it is missing and create is
+// set, a random salt is generated and saved, otherwise records can be neither read nor written.
+func newStorageCipher(files fileBlockStore, passphrase string, create bool) (*storageCipher, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("storage key tidak boleh kosong")
+	}
+	c := &storageCipher{passphrase: passphrase}
+	if err := c.load(files, create); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// reload derives the key again after storage-key.json of files was replaced, e.g. by restore
+func (c *storageCipher) reload(files fileBlockStore) error {
+	return c.load(files, false)
+}
+
+// load reads storage-key.json of files, creating it when create is set, and derives the key
+func (c *storageCipher) load(files fileBlockStore, create bool) error {
+	var params storageKeyParams
+	data, err := files.ReadFile(storageKeyFile)
+	switch {
+	case os.IsNotExist(err) && !create:
+		c.aead = nil
+		return nil
+	case os.IsNotExist(err):
+		salt := make([]byte, storageSaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+		params = storageKeyParams{KDF: storageKDF, Iterations: storageKDFIterations, Salt: hex.EncodeToString(salt)}
+		data, _ := json.MarshalIndent(params, "", "  ")
+		if err := files.WriteFile(storageKeyFile, append(data, '\n'), 0644); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		if err := json.Unmarshal(data, &params); err != nil {
+			return fmt.Errorf("%s: %w", storageKeyFile, err)
+		}
+	}
+
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil || len(salt) == 0 {
+		return fmt.Errorf("%s: salt tidak valid", storageKeyFile)
+	}
+	if params.KDF != storageKDF || params.Iterations < 1 {
+		return fmt.Errorf("%s: kdf %q dengan %d iterasi tidak didukung", storageKeyFile, params.KDF, params.Iterations)
+	}
+	c.aead, err = newGCM(pbkdf2SHA256([]byte(c.passphrase), salt, params.Iterations, 32))
+	return err
+}
+
+// loadStorageKey reads the passphrase from keyFile, falling back to BLOCKCHAIN_STORAGE_KEY
+func loadStorageKey(keyFile string) (string, error) {
+	if keyFile == "" {
+		return os.Getenv("BLOCKCHAIN_STORAGE_KEY"), nil
+	}
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// sealRecord encrypts a stored record with c, or returns it unchanged when c is nil; name
+// is authenticated so records cannot be swapped between files
+func sealRecord(c *storageCipher, name string, plaintext []byte) ([]byte, error) {
+	if c == nil {
+		return plaintext, nil
+	}
+	if c.aead == nil {
+		return nil, fmt.Errorf("storage key belum diinisialisasi: %s tidak ada", storageKeyFile)
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	record := append([]byte(nil), encryptedRecordMagic...)
+	record = append(record, nonce...)
+	return c.aead.Seal(record, nonce, plaintext, []byte(name)), nil
+}
+
+// openRecord decrypts a record written by sealRecord. Plaintext records are returned unchanged
+// when c is nil and rejected when a key is configured.
+func openRecord(c *storageCipher, name string, record []byte) ([]byte, error) {
+	var aead cipher.AEAD
+	switch {
+	case bytes.HasPrefix(record, encryptedRecordMagic) && c != nil:
+		aead, record = c.aead, record[len(encryptedRecordMagic):]
+	case bytes.HasPrefix(record, encryptedRecordMagic):
+		return nil, errStorageKeyRequired
+	case c != nil && !c.acceptPlaintext:
+		return nil, errPlaintextRecord
+	default:
+		return record, nil
+	}
+	if aead == nil {
+		return nil, fmt.Errorf("blok terenkripsi tetapi %s tidak ada", storageKeyFile)
+	}
+	if len(record) < aead.NonceSize() {
+		return nil, fmt.Errorf("blok terenkripsi terpotong")
+	}
+	nonce, ciphertext := record[:aead.NonceSize()], record[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(name))
+	if err != nil {
+		return nil, fmt.Errorf("gagal mendekripsi blok (key salah atau data rusak)")
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestPBKDF2SHA256(t *testing.T) {
+	// Input RFC 6070 dengan HMAC-SHA256 sebagai PRF
+	tests := []struct {
+		password, salt string
+		iterations     int
+		keyLen         int
+		want           string
+	}{
+		{"password", "salt", 1, 32, "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+		{"password", "salt", 2, 32, "ae4d0c95af6b46d32d0adff928f06dd02a303f8ef3c251dfd6e2d85a95474c43"},
+		{"password", "salt", 4096, 32, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+		{"passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, 40, "348c89dbcbd32b2f32d814b8116e84cf2b17347ebc1800181c4e2a1fb8dd53e1c635518c7dac47e9"},
+	}
+	for _, tt := range tests {
+		got := hex.EncodeToString(pbkdf2SHA256([]byte(tt.password), []byte(tt.salt), tt.iterations, tt.keyLen))
+		if got != tt.want {
+			t.Errorf("pbkdf2(%q, %q, %d, %d) = %s, want %s", tt.password, tt.salt, tt.iterations, tt.keyLen, got, tt.want)
+		}
+	}
+}
+
+// testCipher returns the storage cipher for passphrase on a fresh store whose
+// storage-key.json uses few iterations, so tests do not pay for the production count
+func testCipher(t *testing.T, passphrase string) *storageCipher {
+	t.Helper()
+	files := fileBlockStore{dir: t.TempDir()}
+	params := `{"kdf": "pbkdf2-sha256", "iterations": 1000, "salt": "00112233445566778899aabbccddeeff"}`
+	if err := files.WriteFile(storageKeyFile, []byte(params), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := newStorageCipher(files, passphrase, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestSealOpenRecord(t *testing.T) {
+	plaintext := []byte(`{"index": 1, "data": "rahasia"}` + "\n")
+	key := testCipher(t, "kunci")
+	other := testCipher(t, "kunci lain")
+	lenient := *key
+	lenient.acceptPlaintext = true
+	sealed, err := sealRecord(key, "block1.json", plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		cipher  *storageCipher
+		file    string
+		record  []byte
+		wantErr error // nil dan wantBad false berarti record harus terbuka menjadi plaintext
+		wantBad bool  // Harus gagal dengan error apa pun
+	}{
+		{name: "plaintext without key", cipher: nil, file: "block1.json", record: plaintext},
+		{name: "sealed with key", cipher: key, file: "block1.json", record: sealed},
+		{name: "sealed under another file name", cipher: key, file: "block2.json", record: sealed, wantBad: true},
+		{name: "sealed with another passphrase", cipher: other, file: "block1.json", record: sealed, wantBad: true},
+		{name: "sealed without key", cipher: nil, file: "block1.json", record: sealed, wantErr: errStorageKeyRequired},
+		{name: "plaintext with key", cipher: key, file: "block1.json", record: plaintext, wantErr: errPlaintextRecord},
+		{name: "plaintext during db encrypt", cipher: &lenient, file: "block1.json", record: plaintext},
+		{name: "truncated record", cipher: key, file: "block1.json", record: sealed[:len(encryptedRecordMagic)+4], wantBad: true},
+		{name: "tampered ciphertext", cipher: key, file: "block1.json", record: append(append([]byte(nil), sealed[:len(sealed)-1]...), sealed[len(sealed)-1]^1), wantBad: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := openRecord(tt.cipher, tt.file, tt.record)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("openRecord error = %v, want %v", err, tt.wantErr)
+				}
+			case tt.wantBad:
+				if err == nil {
+					t.Fatalf("openRecord accepted the record")
+				}
+			case err != nil:
+				t.Fatalf("openRecord: %v", err)
+			case !bytes.Equal(got, plaintext):
+				t.Errorf("openRecord = %q, want %q", got, plaintext)
+			}
+		})
+	}
+
+	if !bytes.HasPrefix(sealed, encryptedRecordMagic) || len(sealed) != len(plaintext)+encryptedRecordOverhead {
+		t.Errorf("sealed record has %d bytes with prefix %q, want %d bytes with prefix %q", len(sealed), sealed[:len(encryptedRecordMagic)], len(plaintext)+encryptedRecordOverhead, encryptedRecordMagic)
+	}
+}
+
+func TestNewStorageCipherSalt(t *testing.T) {
+	// Tanpa create, store tanpa salt tidak dapat membaca maupun menulis record terenkripsi
+	files := fileBlockStore{dir: t.TempDir()}
+	c, err := newStorageCipher(files, "kunci", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sealRecord(c, "block0.json", []byte("{}")); err == nil {
+		t.Errorf("sealRecord succeeded without %s", storageKeyFile)
+	}
+	if _, err := openRecord(c, "block0.json", append(append([]byte(nil), encryptedRecordMagic...), make([]byte, 40)...)); err == nil {
+		t.Errorf("openRecord succeeded without %s", storageKeyFile)
+	}
+	if _, err := files.ReadFile(storageKeyFile); !os.IsNotExist(err) {
+		t.Errorf("%s written although create was false", storageKeyFile)
+	}
+
+	// Dengan create, salt acak dibuat sekali dan dipakai lagi saat store dibuka ulang
+	if testing.Short() {
+		t.Skip("PBKDF2 dengan jumlah iterasi produksi")
+	}
+	first, err := newStorageCipher(files, "kunci", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := sealRecord(first, "block0.json", []byte("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := newStorageCipher(files, "kunci", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openRecord(reopened, "block0.json", sealed); err != nil {
+		t.Errorf("record unreadable after reopening the store: %v", err)
+	}
+	other, err := newStorageCipher(fileBlockStore{dir: t.TempDir()}, "kunci", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openRecord(other, "block0.json", sealed); err == nil {
+		t.Errorf("record opened with the same passphrase but another store's salt")
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+)
+
+// defaultEpochLength is the number of blocks per epoch for new hybrid chains
+const defaultEpochLength = 10
+
+// epochOf returns the epoch that block index i belongs to; without epochs every block is epoch 0
+func epochOf(cfg GenesisConfig, i int) int {
+	if cfg.EpochLength == 0 {
+		return 0
+	}
+	return i / cfg.EpochLength
+}
+
+// validatorSetAt returns the validator set that finalizes blockchain[i]. With epochs the set is
+// a snapshot taken at the start of the block's epoch, so slashing included during an epoch
+// only takes effect from the next one; without epochs it takes effect from the next block.
+// Delegation blocks follow the same rule.
+func validatorSetAt(cfg GenesisConfig, blockchain []Block, i int) []Validator {
+	boundary := i
+	if cfg.EpochLength > 0 {
+		boundary = epochOf(cfg, i) * cfg.EpochLength
+	}
+	return activeValidators(cfg.Validators, blockchain[:boundary])
+}
+
+func runEpochCommand(store BlockStore, args []string) error {
+	if len(args) != 1 || args[0] != "info" {
+		return fmt.Errorf("penggunaan: epoch info")
+	}
+
+	cfg, err := loadGenesisConfig(store)
+	if err != nil {
+		return err
+	}
+	if cfg.Consensus != consensusHybrid {
+		return fmt.Errorf("epoch hanya tersedia pada konsensus %s", consensusHybrid)
+	}
+	blocks, err := loadBlockchain(store)
+	if err != nil {
+		return err
+	}
+
+	// Blok berikutnya menentukan epoch yang sedang berjalan
+	next := len(blocks)
+	fmt.Println(BoldYellow + "\n=== Epoch ===" + Reset)
+	if cfg.EpochLength == 0 {
+		fmt.Println("Chain ini tidak memakai epoch: perubahan validator set berlaku pada blok berikutnya.")
+	} else {
+		epoch := epochOf(cfg, next)
+		end := (epoch+1)*cfg.EpochLength - 1
+		fmt.Printf("%sPanjang epoch  :%s %d blok\n", BoldCyan, Reset, cfg.EpochLength)
+		fmt.Printf("%sEpoch saat ini :%s %d (blok %d-%d)\n", BoldCyan, Reset, epoch, epoch*cfg.EpochLength, end)
+		fmt.Printf("%sEpoch berikut  :%s dimulai di blok %d (%d blok lagi)\n", BoldCyan, Reset, end+1, end+1-next)
+	}
+
+	current := validatorSetAt(cfg, blocks, next)
+	upcoming := activeValidators(cfg.Validators, blocks)
+	fmt.Println(BoldCyan + "\nValidator set:" + Reset)
+	for i, validator := range current {
+		change := ""
+		if upcoming[i].power() != validator.power() {
+			change = fmt.Sprintf(Yellow+" -> power %d pada epoch berikutnya"+Reset, upcoming[i].power())
+		}
+		fmt.Printf("  %-12s power %-8d%s\n", validator.Name, validator.power(), change)
+	}
+	fmt.Printf("Total power: %d, finalitas membutuhkan lebih dari %d\n", totalStake(current), totalStake(current)*2/3)
+	return nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+// slowBlockWarning is the expected block time above which the menu warns about a difficulty
+const slowBlockWarning = 10 * time.Minute
+
+// Waktu blok yang nyaman untuk demo: cukup lama untuk diamati, cukup singkat untuk ditunggu
+const (
+	suggestedBlockTimeMin = 5 * time.Second
+	suggestedBlockTimeMax = 30 * time.Second
+)
+
+// suggestDifficulty returns the difficulty whose expected block time at hashrate is closest to
+// the suggested range; one hex digit multiplies the work by 16, so the range cannot always be hit
+func suggestDifficulty(hashrate float64) int {
+	middle := math.Sqrt(suggestedBlockTimeMin.Seconds() * suggestedBlockTimeMax.Seconds())
+	best, bestDistance := 1, math.Inf(1)
+	for d := 1; d <= 64; d++ {
+		distance := math.Abs(math.Log(expectedHashes(d) / hashrate / middle))
+		if distance < bestDistance {
+			best, bestDistance = d, distance
+		}
+	}
+	return best
+}
+
+// difficultyTarget returns the numeric target of difficulty: a hash with difficulty leading zero
+// hex digits is exactly a 256-bit number below 16^(64-difficulty) = 2^(256-4*difficulty)
+func difficultyTarget(difficulty int) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(256-4*difficulty))
+}
+
+// targetThreshold returns the largest hash that meets difficulty, in hex
+func targetThreshold(difficulty int) string {
+	return strings.Repeat("0", difficulty) + strings.Repeat("f", 64-difficulty)
+}
+
+// hashToTargetRatio returns hash / target; the hash meets the difficulty when it is below 1
+func hashToTargetRatio(hash string, difficulty int) (float64, bool) {
+	value, ok := new(big.Int).SetString(hash, 16)
+	if !ok {
+		return 0, false
+	}
+	ratio, _ := new(big.Rat).SetFrac(value, difficultyTarget(difficulty)).Float64()
+	return ratio, true
+}
+
+// printDifficultyTarget explains the difficulty of block as a target and compares its hash with it
+func printDifficultyTarget(block Block) {
+	if block.Difficulty < 0 || block.Difficulty > 64 {
+		return
+	}
+	target, _ := new(big.Float).SetInt(difficultyTarget(block.Difficulty)).Float64()
+	fmt.Printf("%sTarget        :%s hash < 2^%d (%.3g)\n", BoldCyan, Reset, 256-4*block.Difficulty, target)
+	fmt.Printf("%sBatas hex     :%s %s\n", BoldCyan, Reset, targetThreshold(block.Difficulty))
+	fmt.Printf("%sPeluang       :%s 1/%.0f per percobaan (%.3g)\n", BoldCyan, Reset, expectedHashes(block.Difficulty), 1/expectedHashes(block.Difficulty))
+	if ratio, ok := hashToTargetRatio(block.Hash, block.Difficulty); ok {
+		verdict := Green + "memenuhi target" + Reset
+		if ratio >= 1 {
+			verdict = Red + "tidak memenuhi target" + Reset
+		}
+		fmt.Printf("%sHash / target :%s %.4f, %s\n", BoldCyan, Reset, ratio, verdict)
+	}
+}
+
+// findProbability returns the chance of finding a block within seconds: block discovery is a
+// Poisson process, so P = 1 - e^(-seconds * hashrate / work)
+func findProbability(difficulty int, hashrate, seconds float64) float64 {
+	return 1 - math.Exp(-seconds*hashrate/expectedHashes(difficulty))
+}
+
+// expectedBlockTime returns the expected time to find a block at difficulty with hashrate
+func expectedBlockTime(difficulty int, hashrate float64) time.Duration {
+	seconds := expectedHashes(difficulty) / hashrate
+	if seconds > math.MaxInt64/float64(time.Second) {
+		return time.Duration(math.MaxInt64)
+	}
+	return durationSeconds(seconds)
+}
+
+// formatExpectedTime prints very long durations in years instead of overflowing hours
+func formatExpectedTime(difficulty int, hashrate float64) string {
+	seconds := expectedHashes(difficulty) / hashrate
+	if year := 365.25 * 24 * 3600; seconds > year {
+		return fmt.Sprintf("%.3g tahun", seconds/year)
+	}
+	return expectedBlockTime(difficulty, hashrate).String()
+}
+
+// warnSlowDifficulty measures the hashrate of cfg's PoW briefly and warns when difficulty would take long to mine
+func warnSlowDifficulty(cfg GenesisConfig, difficulty int) {
+	hashrate := measureHashrate(cfg, 200*time.Millisecond, benchMidstate)
+	if expectedHashes(difficulty)/hashrate > slowBlockWarning.Seconds() {
+		fmt.Printf(Yellow+"Peringatan: dengan hashrate mesin ini (~%.0f H/s) satu blok pada kesulitan %d diperkirakan butuh %s (lihat perintah estimate).\n"+Reset,
+			hashrate, difficulty, formatExpectedTime(difficulty, hashrate))
+	}
+}
+
+// firstRunDifficulty benchmarks the PoW of cfg on this machine briefly and suggests a difficulty
+// giving 5-30 second blocks; on a terminal the user confirms it, otherwise the suggestion is used
+func firstRunDifficulty(cfg GenesisConfig, reader *bufio.Reader, fallback int, interactive bool) int {
+	fmt.Printf(Yellow+"Mengukur hashrate %s mesin ini untuk chain baru...\n"+Reset, cfg.PoW)
+	hashrate := measureHashrate(cfg, time.Second, benchMidstate)
+	suggested := suggestDifficulty(hashrate)
+	fmt.Printf(Green+"Hashrate %.0f H/s: tingkat kesulitan %d menghasilkan blok rata-rata setiap %s (default %d: %s).\n"+Reset,
+		hashrate, suggested, formatExpectedTime(suggested, hashrate), fallback, formatExpectedTime(fallback, hashrate))
+
+	// Input dari pipe atau file tidak ditanya agar skrip menu tetap berjalan
+	if info, err := os.Stdin.Stat(); !interactive || err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return suggested
+	}
+	fmt.Printf(BoldCyan+"Gunakan tingkat kesulitan %d? (Y/n): "+Reset, suggested)
+	answer, _ := reader.ReadString('\n')
+	if strings.EqualFold(strings.TrimSpace(answer), "n") {
+		return fallback
+	}
+	return suggested
+}
+
+func runEstimateCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("estimate", flag.ContinueOnError)
+	difficulty := fs.Int("difficulty", -1, "Tingkat kesulitan yang diperkirakan (default: tabel 1-10)")
+	hashrate := fs.Float64("hashrate", 0, "Hashrate dalam hash per detik (default: diukur di mesin ini)")
+	within := fs.Duration("within", time.Minute, "Hitung peluang menemukan blok dalam waktu ini")
+	duration := fs.Duration("duration", time.Second, "Lama pengukuran hashrate jika -hashrate tidak diberikan")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *difficulty > 64 || *hashrate < 0 || *within <= 0 {
+		return fmt.Errorf("difficulty harus antara 0 dan 64, hashrate >= 0, dan within > 0")
+	}
+
+	// Algoritma PoW chain lokal menentukan hashrate yang relevan
+	cfg, err := loadGenesisConfig(store)
+	if err != nil {
+		return err
+	}
+	if *hashrate == 0 {
+		fmt.Printf(Yellow+"Mengukur hashrate %s selama %s...\n"+Reset, cfg.PoW, *duration)
+		*hashrate = measureHashrate(cfg, *duration, benchMidstate)
+	}
+
+	difficulties := []int{*difficulty}
+	if *difficulty < 0 {
+		difficulties = nil
+		for d := 1; d <= 10; d++ {
+			difficulties = append(difficulties, d)
+		}
+	}
+
+	fmt.Printf(BoldYellow+"\n=== Perkiraan Mining (%.0f H/s, PoW %s) ===\n"+Reset, *hashrate, cfg.PoW)
+	fmt.Printf("%s%-10s %-16s %-20s %s%s\n", BoldCyan, "Kesulitan", "Percobaan", "Waktu rata-rata", fmt.Sprintf("P(<= %s)", *within), Reset)
+	for _, d := range difficulties {
+		color := Green
+		if expectedHashes(d) / *hashrate > slowBlockWarning.Seconds() {
+			color = Red
+		}
+		fmt.Printf("%s%-10d %-16.4g %-20s %.2f%%%s\n", color, d, expectedHashes(d), formatExpectedTime(d, *hashrate), findProbability(d, *hashrate, within.Seconds())*100, Reset)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Jenis event yang dipublikasikan node
+const (
+	eventBlock             = "block"              // Blok baru ditambahkan ke blockchain
+	eventJob               = "job"                // Status pekerjaan mining berubah
+	eventValidationFailure = "validation-failure" // Blok ditolak oleh aturan validasi
+)
+
+// chainEvent is a notification about a change to the chain or the mining queue
+type chainEvent struct {
+	Type  string     `json:"type"`
+	Time  time.Time  `json:"time"`
+	Block *Block     `json:"block,omitempty"`
+	Job   *miningJob `json:"job,omitempty"`
+	Rule  string     `json:"rule,omitempty"`  // Aturan yang dilanggar pada validation-failure
+	Error string     `json:"error,omitempty"` // Pesan kesalahan pada validation-failure
+}
+
+// subscriberBuffer is how many events a slow subscriber may fall behind before events are dropped
+const subscriberBuffer = 64
+
+// eventBus fans events out to subscribers; the zero value is ready to use
+type eventBus struct {
+	mu          sync.Mutex
+	subscribers map[chan chainEvent]struct{}
+}
+
+// Subscribe returns a channel receiving every published event and a function that unsubscribes it
+func (b *eventBus) Subscribe() (<-chan chainEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subscribers == nil {
+		b.subscribers = make(map[chan chainEvent]struct{})
+	}
+	ch := make(chan chainEvent, subscriberBuffer)
+	b.subscribers[ch] = struct{}{}
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// Publish sends event to every subscriber without blocking the publisher
+func (b *eventBus) Publish(event chainEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	event.Time = time.Now()
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Subscriber terlalu lambat; event dibuang agar mining tidak tertahan
+		}
+	}
+}
+
+// sseKeepAlive is how often a comment is sent on idle event streams so proxies keep them open
+const sseKeepAlive = 15 * time.Second
+
+// handleEvents streams events as Server-Sent Events; ?types=block,job limits the event types
+func (s *apiServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming tidak didukung")
+		return
+	}
+
+	types := make(map[string]bool)
+	for _, name := range strings.Split(r.URL.Query().Get("types"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			types[name] = true
+		}
+	}
+
+	events, unsubscribe := s.chain.events.Subscribe()
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case event := <-events:
+			if len(types) > 0 && !types[event.Type] {
+				continue
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,332 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// simBlock is a block in the simulated multi-miner block tree
+type simBlock struct {
+	parent int // Index parent di slice blok, -1 untuk genesis
+	height int
+	miner  int
+	time   float64 // Waktu penemuan dalam detik
+}
+
+// orphanResult is the outcome of one orphan-rate simulation run
+type orphanResult struct {
+	Interval  float64
+	Latency   float64
+	Blocks    int
+	Orphans   int
+	Rate      float64
+	Predicted float64
+}
+
+// simulateOrphans mines blocks with several equal-hashrate miners that only see each
+// other's blocks after latency seconds; blocks outside the final longest chain are orphans
+func simulateOrphans(rng *rand.Rand, miners, blocks int, interval, latency float64) orphanResult {
+	tree := []simBlock{{parent: -1, height: 0, miner: -1, time: 0}}
+	visible := 0    // Blok terbaik yang sudah terlihat oleh semua miner
+	propagated := 1 // Blok dengan index < propagated sudah sampai ke semua miner
+	now := 0.0
+
+	for len(tree) <= blocks {
+		now += rng.ExpFloat64() * interval
+		miner := rng.Intn(miners)
+
+		// Blok yang sudah cukup lama dipropagasikan terlihat oleh semua miner
+		for propagated < len(tree) && tree[propagated].time+latency <= now {
+			if tree[propagated].height > tree[visible].height {
+				visible = propagated
+			}
+			propagated++
+		}
+
+		// Blok milik sendiri langsung terlihat walaupun belum dipropagasikan
+		tip := visible
+		for i := propagated; i < len(tree); i++ {
+			if tree[i].miner == miner && tree[i].height > tree[tip].height {
+				tip = i
+			}
+		}
+
+		tree = append(tree, simBlock{parent: tip, height: tree[tip].height + 1, miner: miner, time: now})
+	}
+
+	// Rantai utama adalah rantai terpanjang; blok lain menjadi orphan
+	best := 0
+	for i := range tree {
+		if tree[i].height > tree[best].height {
+			best = i
+		}
+	}
+	mined := len(tree) - 1
+	orphans := mined - tree[best].height
+
+	return orphanResult{
+		Interval:  interval,
+		Latency:   latency,
+		Blocks:    mined,
+		Orphans:   orphans,
+		Rate:      float64(orphans) / float64(mined),
+		Predicted: 1 - math.Exp(-latency/interval),
+	}
+}
+
+// parseFloatList parses a comma separated list such as "0.5,1,2"
+func parseFloatList(value string) ([]float64, error) {
+	var values []float64
+	for _, field := range strings.Split(value, ",") {
+		number, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil || number < 0 {
+			return nil, fmt.Errorf("nilai tidak valid %q", field)
+		}
+		values = append(values, number)
+	}
+	return values, nil
+}
+
+func runExperimentCommand(store BlockStore, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("penggunaan: experiment orphans|retarget|bft|doublespend [opsi]")
+	}
+	switch args[0] {
+	case "orphans":
+		return runOrphanExperiment(args[1:])
+	case "retarget":
+		return runRetargetExperiment(args[1:])
+	case "bft":
+		return runBFTExperiment(args[1:])
+	case "doublespend":
+		return runDoubleSpendExperiment(args[1:])
+	default:
+		return fmt.Errorf("eksperimen tidak dikenal: %q", args[0])
+	}
+}
+
+func runOrphanExperiment(args []string) error {
+	fs := flag.NewFlagSet("experiment orphans", flag.ContinueOnError)
+	latencies := fs.String("latencies", "0.5,2,5,10", "Daftar latensi propagasi dalam detik")
+	intervals := fs.String("intervals", "15,60,150,600", "Daftar target interval blok dalam detik")
+	blocks := fs.Int("blocks", 10000, "Jumlah blok per kombinasi")
+	miners := fs.Int("miners", 10, "Jumlah miner dengan hashrate sama")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "Seed generator acak")
+	csvPath := fs.String("csv", "", "Simpan hasil ke file CSV")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	latencyList, err := parseFloatList(*latencies)
+	if err != nil {
+		return err
+	}
+	intervalList, err := parseFloatList(*intervals)
+	if err != nil {
+		return err
+	}
+	if *blocks < 1 || *miners < 1 {
+		return fmt.Errorf("blocks dan miners harus >= 1")
+	}
+
+	rng := rand.New(rand.NewSource(*seed))
+	var results []orphanResult
+	for _, interval := range intervalList {
+		if interval <= 0 {
+			return fmt.Errorf("interval blok harus > 0")
+		}
+		for _, latency := range latencyList {
+			results = append(results, simulateOrphans(rng, *miners, *blocks, interval, latency))
+		}
+	}
+
+	fmt.Println(BoldYellow + "\n=== Eksperimen Orphan Rate ===" + Reset)
+	fmt.Printf("%d miner, %d blok per kombinasi, seed %d\n", *miners, *blocks, *seed)
+	fmt.Printf("%s%10s %10s %8s %10s %12s%s\n", BoldCyan, "Interval", "Latensi", "Orphan", "Rate", "Prediksi", Reset)
+	for _, result := range results {
+		fmt.Printf("%9.1fs %9.1fs %8d %9.2f%% %11.2f%%\n", result.Interval, result.Latency, result.Orphans, result.Rate*100, result.Predicted*100)
+	}
+	fmt.Println(Yellow + "Prediksi: 1 - e^(-latensi/interval), peluang blok lain ditemukan selama propagasi." + Reset)
+
+	if *csvPath != "" {
+		return writeOrphanCSV(*csvPath, results)
+	}
+	return nil
+}
+
+// writeOrphanCSV writes one row per interval/latency combination
+func writeOrphanCSV(path string, results []orphanResult) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"interval_seconds", "latency_seconds", "blocks", "orphans", "orphan_rate", "predicted_rate"})
+	for _, r := range results {
+		writer.Write([]string{
+			strconv.FormatFloat(r.Interval, 'f', -1, 64),
+			strconv.FormatFloat(r.Latency, 'f', -1, 64),
+			strconv.Itoa(r.Blocks),
+			strconv.Itoa(r.Orphans),
+			strconv.FormatFloat(r.Rate, 'f', 6, 64),
+			strconv.FormatFloat(r.Predicted, 'f', 6, 64),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// retargetResult summarizes how one algorithm reacted to a hashrate shock
+type retargetResult struct {
+	Algorithm    string
+	BeforeMean   float64 // Rata-rata waktu blok sebelum shock
+	AfterMean    float64 // Rata-rata waktu blok setelah shock
+	Overshoot    float64 // Rata-rata bergulir tertinggi setelah shock, relatif terhadap target
+	Undershoot   float64 // Rata-rata bergulir terendah setelah shock, relatif terhadap target
+	RecoveryBlks int     // Blok sampai rata-rata bergulir kembali dalam toleransi, -1 jika tidak pernah
+}
+
+// retargetTrace is one simulated block for CSV output
+type retargetTrace struct {
+	Height    int
+	Work      float64
+	SolveTime float64
+	Hashrate  float64
+}
+
+// simulateRetarget mines blocks whose discovery time follows the work set by the algorithm,
+// multiplying the hashrate by shock at block shockAt
+func simulateRetarget(rng *rand.Rand, algorithm Retargeter, blocks, shockAt int, shock, hashrate, target float64, window int, tolerance float64) (retargetResult, []retargetTrace) {
+	history := []retargetSample{{Height: 0, Work: hashrate * target, Time: 0}}
+	traces := make([]retargetTrace, 0, blocks)
+	solveTimes := make([]float64, 0, blocks)
+
+	for height := 1; height <= blocks; height++ {
+		rate := hashrate
+		if height >= shockAt {
+			rate = hashrate * shock
+		}
+		work := algorithm.NextWork(history, target)
+		solveTime := sampleBlockTime(rng, work, rate)
+		history = append(history, retargetSample{Height: height, Work: work, Time: history[len(history)-1].Time + solveTime})
+		solveTimes = append(solveTimes, solveTime)
+		traces = append(traces, retargetTrace{Height: height, Work: work, SolveTime: solveTime, Hashrate: rate})
+	}
+
+	result := retargetResult{Algorithm: algorithm.Name(), RecoveryBlks: -1, Undershoot: math.Inf(1)}
+	result.BeforeMean = meanOf(solveTimes[:shockAt-1])
+	result.AfterMean = meanOf(solveTimes[shockAt-1:])
+
+	// Rata-rata bergulir setelah shock untuk mengukur osilasi dan pemulihan
+	for end := shockAt - 1 + window; end <= len(solveTimes); end++ {
+		rolling := meanOf(solveTimes[end-window:end]) / target
+		result.Overshoot = math.Max(result.Overshoot, rolling)
+		result.Undershoot = math.Min(result.Undershoot, rolling)
+		if result.RecoveryBlks < 0 && math.Abs(rolling-1) <= tolerance {
+			result.RecoveryBlks = end - (shockAt - 1)
+		}
+	}
+	if math.IsInf(result.Undershoot, 1) {
+		result.Undershoot = 0
+	}
+	return result, traces
+}
+
+// meanOf returns the arithmetic mean of values, or 0 for an empty slice
+func meanOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var total float64
+	for _, value := range values {
+		total += value
+	}
+	return total / float64(len(values))
+}
+
+func runRetargetExperiment(args []string) error {
+	fs := flag.NewFlagSet("experiment retarget", flag.ContinueOnError)
+	algorithms := fs.String("algorithms", "bitcoin,ema,lwma", "Daftar algoritma retarget: "+strings.Join(retargeterNames(), ", "))
+	blocks := fs.Int("blocks", 6000, "Jumlah blok per algoritma")
+	shockAt := fs.Int("shock-at", 3000, "Tinggi blok saat hashrate berubah")
+	shock := fs.Float64("shock", 2, "Faktor perubahan hashrate (2 berarti dua kali lipat, 0.5 berarti setengah)")
+	hashrate := fs.Float64("hashrate", 1e6, "Hashrate awal (hash per detik)")
+	target := fs.Float64("target", 60, "Target interval blok dalam detik")
+	window := fs.Int("window", 50, "Jumlah blok untuk rata-rata bergulir")
+	tolerance := fs.Float64("tolerance", 0.2, "Toleransi relatif untuk dianggap pulih")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "Seed generator acak")
+	csvPath := fs.String("csv", "", "Simpan jejak setiap blok ke file CSV")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *shockAt < 2 || *shockAt > *blocks || *shock <= 0 || *hashrate <= 0 || *target <= 0 || *window < 1 {
+		return fmt.Errorf("parameter eksperimen tidak valid")
+	}
+
+	var results []retargetResult
+	traces := make(map[string][]retargetTrace)
+	var names []string
+	for _, name := range strings.Split(*algorithms, ",") {
+		algorithm, err := newRetargeter(strings.TrimSpace(name))
+		if err != nil {
+			return err
+		}
+		// Setiap algoritma memakai seed yang sama agar perbandingannya adil
+		rng := rand.New(rand.NewSource(*seed))
+		result, trace := simulateRetarget(rng, algorithm, *blocks, *shockAt, *shock, *hashrate, *target, *window, *tolerance)
+		results = append(results, result)
+		traces[algorithm.Name()] = trace
+		names = append(names, algorithm.Name())
+	}
+
+	fmt.Println(BoldYellow + "\n=== Eksperimen Osilasi Difficulty ===" + Reset)
+	fmt.Printf("Hashrate x%.2f pada blok %d dari %d, target %.0fs, seed %d\n", *shock, *shockAt, *blocks, *target, *seed)
+	fmt.Printf("%s%-10s %12s %12s %11s %11s %10s%s\n", BoldCyan, "Algoritma", "Sebelum", "Sesudah", "Overshoot", "Undershoot", "Pulih", Reset)
+	for _, r := range results {
+		recovery := "tidak"
+		if r.RecoveryBlks >= 0 {
+			recovery = strconv.Itoa(r.RecoveryBlks) + " blok"
+		}
+		fmt.Printf("%-10s %11.1fs %11.1fs %10.0f%% %10.0f%% %10s\n", r.Algorithm, r.BeforeMean, r.AfterMean, r.Overshoot*100, r.Undershoot*100, recovery)
+	}
+	fmt.Printf(Yellow+"Overshoot/undershoot: rata-rata %d blok tertinggi/terendah setelah shock, relatif terhadap target.\n"+Reset, *window)
+
+	if *csvPath != "" {
+		return writeRetargetCSV(*csvPath, names, traces)
+	}
+	return nil
+}
+
+// writeRetargetCSV writes one row per simulated block per algorithm
+func writeRetargetCSV(path string, names []string, traces map[string][]retargetTrace) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"algorithm", "height", "work", "solve_time_seconds", "hashrate"})
+	for _, name := range names {
+		for _, t := range traces[name] {
+			writer.Write([]string{
+				name,
+				strconv.Itoa(t.Height),
+				strconv.FormatFloat(t.Work, 'f', 0, 64),
+				strconv.FormatFloat(t.SolveTime, 'f', 3, 64),
+				strconv.FormatFloat(t.Hashrate, 'f', 0, 64),
+			})
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// feedEntries is how many recent blocks the Atom feed contains
+const feedEntries = 20
+
+// atomFeed is the subset of RFC 4287 needed for a feed of blocks
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title   string   `xml:"title"`
+	ID      string   `xml:"id"`
+	Updated string   `xml:"updated"`
+	Link    atomLink `xml:"link"`
+	Summary string   `xml:"summary"`
+}
+
+// blockFeed builds an Atom feed of the most recent blocks, newest first; baseURL is used for links
+func blockFeed(blocks []Block, baseURL string) atomFeed {
+	genesis := blocks[0]
+	tip := blocks[len(blocks)-1]
+	feed := atomFeed{
+		Title:   "Blockchain " + genesis.Hash[:16],
+		ID:      "urn:blockchain-simulation:chain:" + genesis.Hash,
+		Updated: feedTime(tip.Timestamp),
+		Link:    atomLink{Href: baseURL + "/feed.atom", Rel: "self"},
+		Author:  atomAuthor{Name: "blockchain-simulation"},
+	}
+
+	for i := len(blocks) - 1; i >= 0 && i >= len(blocks)-feedEntries; i-- {
+		block := blocks[i]
+		summary := fmt.Sprintf("Blok %d, hash %s, difficulty %d, data %q", block.Index, block.Hash, block.Difficulty, block.Data)
+		if block.ExtraData != "" {
+			summary += fmt.Sprintf(", extra data %q", block.ExtraData)
+		}
+		if len(block.Signatures) > 0 {
+			summary += fmt.Sprintf(", difinalisasi oleh %d validator", len(block.Signatures))
+		}
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:   fmt.Sprintf("Blok %d", block.Index),
+			ID:      "urn:blockchain-simulation:block:" + block.Hash,
+			Updated: feedTime(block.Timestamp),
+			Link:    atomLink{Href: fmt.Sprintf("%s/blocks/%d", baseURL, block.Index)},
+			Summary: summary,
+		})
+	}
+	return feed
+}
+
+// feedTime converts a block timestamp to the RFC 3339 form Atom requires
+func feedTime(timestamp string) string {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return time.Unix(0, 0).UTC().Format(time.RFC3339)
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func (s *apiServer) handleFeed(w http.ResponseWriter, r *http.Request) {
+	blocks := s.chain.Blocks()
+	if notModified(w, r, "feed:"+blocks[len(blocks)-1].Hash) {
+		return
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	data, err := xml.MarshalIndent(blockFeed(blocks, scheme+"://"+r.Host), "", "  ")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	w.Write(data)
+}
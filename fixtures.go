@@ -0,0 +1,191 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// fixtureEpoch is the timestamp of the fixture genesis block; later blocks follow every fixtureInterval
+var fixtureEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+const fixtureInterval = 30 * time.Second
+
+// goldenFile lists the expected canonical bytes and hash of every fixture block
+const goldenFile = "golden.json"
+
+// goldenBlock is the expected encoding of one fixture block
+type goldenBlock struct {
+	Index  int    `json:"index"`
+	Record string `json:"record"` // Hex dari byte yang di-hash (lihat blockRecord)
+	Hash   string `json:"hash"`
+}
+
+// goldenChain is the content of golden.json
+type goldenChain struct {
+	Seed       int64         `json:"seed"`
+	Difficulty int           `json:"difficulty"`
+	Genesis    GenesisConfig `json:"genesis"`
+	Blocks     []goldenBlock `json:"blocks"`
+}
+
+// fixtureGenesisConfig is the genesis config of the fixture chain and the conformance vectors
+func fixtureGenesisConfig() GenesisConfig {
+	cfg := defaultGenesisConfig
+	cfg.MedianTimeSpan = defaultMedianTimeSpan
+	return cfg
+}
+
+// mineFixtureBlock searches nonces from 0 with a fixed timestamp on one goroutine, so the same
+// input always gives the same block
+func mineFixtureBlock(cfg GenesisConfig, index int, timestamp time.Time, data string, previousHash string, difficulty int) Block {
+	block := Block{Index: index, Timestamp: timestamp.Format(time.RFC3339), Data: data, PreviousHash: previousHash, Difficulty: difficulty}
+	prefix := strings.Repeat("0", difficulty)
+	for {
+		block.Hash = calculateHash(cfg, block)
+		if strings.HasPrefix(block.Hash, prefix) {
+			return block
+		}
+		block.Nonce++
+	}
+}
+
+// fixtureChain builds the deterministic reference chain for seed on a chain with genesis config cfg
+func fixtureChain(cfg GenesisConfig, seed int64, count, difficulty int) []Block {
+	words := []string{"alice", "bob", "carol", "dave", "kirim", "terima", "blok", "koin", "catatan", "nilai"}
+	rng := rand.New(rand.NewSource(seed))
+
+	blockchain := []Block{mineFixtureBlock(cfg, 0, fixtureEpoch, "Genesis Block", genesisPreviousHash, difficulty)}
+	for i := 1; i < count; i++ {
+		data := fmt.Sprintf("%s %s %d", words[rng.Intn(len(words))], words[rng.Intn(len(words))], rng.Intn(1000))
+		timestamp := fixtureEpoch.Add(time.Duration(i) * fixtureInterval)
+		blockchain = append(blockchain, mineFixtureBlock(cfg, i, timestamp, data, blockchain[i-1].Hash, difficulty))
+	}
+	return blockchain
+}
+
+func runFixturesCommand(store BlockStore, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("penggunaan: fixtures generate|check [opsi]")
+	}
+	switch args[0] {
+	case "generate":
+		return runFixturesGenerate(args[1:])
+	case "check":
+		return runFixturesCheck(args[1:])
+	default:
+		return fmt.Errorf("perintah fixtures tidak dikenal: %q", args[0])
+	}
+}
+
+// runFixturesGenerate writes the reference chain to <out>/chain and its expected encodings to <out>/golden.json
+func runFixturesGenerate(args []string) error {
+	fs := flag.NewFlagSet("fixtures generate", flag.ContinueOnError)
+	out := fs.String("out", "testdata", "Direktori tujuan fixture")
+	count := fs.Int("blocks", 10, "Jumlah blok termasuk genesis")
+	difficulty := fs.Int("difficulty", 3, "Tingkat kesulitan setiap blok")
+	seed := fs.Int64("seed", 1, "Seed untuk isi Data blok")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *count < 1 || *difficulty < 0 || *difficulty > 6 {
+		return fmt.Errorf("blocks harus >= 1 dan difficulty antara 0 dan 6")
+	}
+
+	cfg := fixtureGenesisConfig()
+	blockchain := fixtureChain(cfg, *seed, *count, *difficulty)
+
+	// Blok ditulis tanpa enkripsi dengan format penyimpanan biasa agar dapat dibuka dengan -datadir
+	store := fileBlockStore{dir: filepath.Join(*out, "chain")}
+	if err := os.RemoveAll(store.dir); err != nil {
+		return err
+	}
+	golden := goldenChain{Seed: *seed, Difficulty: *difficulty, Genesis: cfg}
+	for _, block := range blockchain {
+		if err := store.SaveBlock(block); err != nil {
+			return err
+		}
+		golden.Blocks = append(golden.Blocks, goldenBlock{Index: block.Index, Record: hex.EncodeToString(blockRecord(block)), Hash: block.Hash})
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := store.WriteFile(genesisConfigFile, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	data, err = json.MarshalIndent(golden, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(*out, goldenFile), append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	fmt.Printf(Green+"%d blok fixture ditulis ke %s, hash yang diharapkan di %s.\n"+Reset, len(blockchain), store.dir, filepath.Join(*out, goldenFile))
+	return nil
+}
+
+// runFixturesCheck loads the fixture chain and compares the current encoding, hashing and
+// validation against golden.json, so a refactor that changes any of them is noticed
+func runFixturesCheck(args []string) error {
+	fs := flag.NewFlagSet("fixtures check", flag.ContinueOnError)
+	dir := fs.String("dir", "testdata", "Direktori fixture")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(filepath.Join(*dir, goldenFile))
+	if err != nil {
+		return err
+	}
+	var golden goldenChain
+	if err := json.Unmarshal(data, &golden); err != nil {
+		return fmt.Errorf("%s: %w", goldenFile, err)
+	}
+	cfg := golden.Genesis
+	blockchain, err := fileBlockStore{dir: filepath.Join(*dir, "chain")}.LoadBlocks()
+	if err != nil {
+		return err
+	}
+
+	// Fixture juga dibangun ulang dari seed untuk mendeteksi perubahan pada proses mining
+	rebuilt := fixtureChain(cfg, golden.Seed, len(golden.Blocks), golden.Difficulty)
+	failures := 0
+	fail := func(format string, args ...any) {
+		failures++
+		fmt.Printf(Red+"[FAIL]  "+format+"\n"+Reset, args...)
+	}
+	if len(blockchain) != len(golden.Blocks) {
+		fail("chain berisi %d blok, golden %d", len(blockchain), len(golden.Blocks))
+	}
+	for i, expected := range golden.Blocks {
+		if i >= len(blockchain) {
+			break
+		}
+		block := blockchain[i]
+		if record := hex.EncodeToString(blockRecord(block)); record != expected.Record {
+			fail("blok %d: encoding kanonik berubah", i)
+		}
+		if hash := calculateHash(cfg, block); hash != expected.Hash || block.Hash != expected.Hash {
+			fail("blok %d: hash %s, golden %s", i, hash, expected.Hash)
+		}
+		if rebuilt[i].Hash != expected.Hash {
+			fail("blok %d: mining ulang dari seed menghasilkan %s", i, rebuilt[i].Hash)
+		}
+	}
+	if _, rule, err := verifyChain(cfg, blockchain, verifyParanoid); err != nil {
+		fail("validasi gagal: [%s] %v", rule, err)
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d pemeriksaan fixture gagal", failures)
+	}
+	fmt.Printf(Green+"[OK]    %d blok sesuai dengan %s\n"+Reset, len(golden.Blocks), filepath.Join(*dir, goldenFile))
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// TestFixturesGolden regenerates the fixture chain with the parameters recorded in
+// testdata/golden.json and requires byte-identical output, so any change to mining,
+// serialization or hashing shows up as a diff against the committed baseline
+func TestFixturesGolden(t *testing.T) {
+	want, err := os.ReadFile(filepath.Join("testdata", goldenFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var golden goldenChain
+	if err := json.Unmarshal(want, &golden); err != nil {
+		t.Fatalf("%s: %v", goldenFile, err)
+	}
+
+	out := t.TempDir()
+	args := []string{
+		"-out", out,
+		"-blocks", strconv.Itoa(len(golden.Blocks)),
+		"-difficulty", strconv.Itoa(golden.Difficulty),
+		"-seed", strconv.FormatInt(golden.Seed, 10),
+	}
+	if err := runFixturesGenerate(args); err != nil {
+		t.Fatalf("fixtures generate: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(out, goldenFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("regenerated %s differs from testdata; run \"fixtures generate\" if the change is intended", goldenFile)
+	}
+
+	// File chain juga harus sama persis, termasuk genesis.json
+	files, err := filepath.Glob(filepath.Join("testdata", "chain", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != len(golden.Blocks)+1 {
+		t.Errorf("testdata/chain has %d files, want %d blocks and %s", len(files), len(golden.Blocks), genesisConfigFile)
+	}
+	for _, file := range files {
+		want, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(filepath.Join(out, "chain", filepath.Base(file)))
+		if err != nil {
+			t.Errorf("%s was not regenerated: %v", filepath.Base(file), err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("regenerated %s differs from testdata", filepath.Base(file))
+		}
+	}
+}
+
+// TestFixturesCheck runs the fixtures check command against the committed fixtures
+func TestFixturesCheck(t *testing.T) {
+	if err := runFixturesCheck([]string{"-dir", "testdata"}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"time"
+)
+
+// retargetManual keeps the original behaviour: the user picks the difficulty of every block
+const retargetManual = "manual"
+
+// genesisConfigFile holds the chain parameters chosen when the genesis block was created
+const genesisConfigFile = "genesis.json"
+
+// GenesisConfig holds parameters fixed for the lifetime of a chain. It is loaded once per chain
+// and passed explicitly to the code that needs it; nothing reassigns it while the chain runs.
+type GenesisConfig struct {
+	Retarget       string  `json:"retarget"`        // manual atau nama algoritma di retargeters
+	TargetInterval float64 `json:"target_interval"` // Target interval blok dalam detik
+	PoW            string  `json:"pow"`             // Nama fungsi proof-of-work di powAlgorithms
+	PoWMemoryKiB   int     `json:"pow_memory_kib,omitempty"`
+
+	Consensus  string      `json:"consensus"`            // pow atau hybrid
+	Validators []Validator `json:"validators,omitempty"` // Validator set untuk konsensus hybrid
+	// Jumlah blok per epoch; perubahan validator set berlaku mulai epoch berikutnya (0 berarti langsung)
+	EpochLength int     `json:"epoch_length,omitempty"`
+	BlockReward float64 `json:"block_reward"` // Reward per blok yang dibagi validator penanda tangan
+
+	MaxDataSize int `json:"max_data_size,omitempty"` // Ukuran maksimum Data blok dalam byte (0 berarti tanpa batas)
+	// Timestamp blok harus melewati median timestamp sekian blok terakhir (0 berarti cukup tidak mundur dari blok sebelumnya)
+	MedianTimeSpan   int `json:"median_time_span,omitempty"`
+	MaxFutureSeconds int `json:"max_future_seconds"` // Seberapa jauh timestamp blok boleh mendahului jam lokal
+}
+
+// defaultGenesisConfig is used for chains created before genesis.json existed, and for the
+// parameters a genesis.json written by an older version does not set
+var defaultGenesisConfig = GenesisConfig{Retarget: retargetManual, TargetInterval: 30, PoW: powSHA256, Consensus: consensusPoW, BlockReward: 50, MaxFutureSeconds: 2 * 60 * 60}
+
+// validate checks that the configuration names a known algorithm
+func (cfg GenesisConfig) validate() error {
+	if cfg.Retarget != retargetManual {
+		if _, err := newRetargeter(cfg.Retarget); err != nil {
+			return err
+		}
+	}
+	if cfg.TargetInterval <= 0 {
+		return fmt.Errorf("target_interval harus > 0")
+	}
+	if cfg.BlockReward < 0 {
+		return fmt.Errorf("block_reward tidak boleh negatif")
+	}
+	if cfg.MedianTimeSpan < 0 {
+		return fmt.Errorf("median_time_span tidak boleh negatif")
+	}
+	if cfg.MaxDataSize < 0 {
+		return fmt.Errorf("max_data_size tidak boleh negatif")
+	}
+	if cfg.MaxFutureSeconds < 0 {
+		return fmt.Errorf("max_future_seconds tidak boleh negatif")
+	}
+	if _, ok := powAlgorithms[cfg.PoW]; !ok {
+		return fmt.Errorf("algoritma proof-of-work tidak dikenal: %q (tersedia: %v)", cfg.PoW, powAlgorithmNames())
+	}
+	if cfg.PoW == powMemHard && cfg.PoWMemoryKiB < 1 {
+		return fmt.Errorf("pow_memory_kib harus >= 1 untuk PoW %s", powMemHard)
+	}
+	switch cfg.Consensus {
+	case consensusPoW:
+	case consensusHybrid:
+		if totalStake(cfg.Validators) == 0 {
+			return fmt.Errorf("konsensus %s membutuhkan validator dengan stake (lihat perintah validator keygen)", consensusHybrid)
+		}
+		if cfg.EpochLength < 0 {
+			return fmt.Errorf("epoch_length tidak boleh negatif")
+		}
+		for _, validator := range cfg.Validators {
+			if validator.Commission < 0 || validator.Commission > 1 {
+				return fmt.Errorf("komisi validator %q harus antara 0 dan 1", validator.Name)
+			}
+		}
+	default:
+		return fmt.Errorf("konsensus tidak dikenal: %q (gunakan %s atau %s)", cfg.Consensus, consensusPoW, consensusHybrid)
+	}
+	return nil
+}
+
+// maxFutureBlockTime is how far ahead of the local clock a block timestamp may be
+func (cfg GenesisConfig) maxFutureBlockTime() time.Duration {
+	return time.Duration(cfg.MaxFutureSeconds) * time.Second
+}
+
+// checkDataSize rejects block data larger than the chain allows
+func (cfg GenesisConfig) checkDataSize(data string) error {
+	if cfg.MaxDataSize > 0 && len(data) > cfg.MaxDataSize {
+		return fmt.Errorf("data blok %d byte melebihi batas chain %d byte", len(data), cfg.MaxDataSize)
+	}
+	return nil
+}
+
+// dataSizeWarnRatio is the share of max_data_size from which block data is reported as near the limit
+const dataSizeWarnRatio = 0.9
+
+// dataSizeWarning describes block data that is within reach of the chain's size limit, or
+// returns "" when it is comfortably below it or the chain has no limit
+func (cfg GenesisConfig) dataSizeWarning(size int) string {
+	if cfg.MaxDataSize <= 0 || float64(size) < dataSizeWarnRatio*float64(cfg.MaxDataSize) {
+		return ""
+	}
+	return fmt.Sprintf("data blok %d byte mendekati batas chain %d byte (%.0f%%)", size, cfg.MaxDataSize, 100*float64(size)/float64(cfg.MaxDataSize))
+}
+
+// loadGenesisConfig reads genesis.json from store, falling back to the defaults
+func loadGenesisConfig(store BlockStore) (GenesisConfig, error) {
+	data, err := store.ReadFile(genesisConfigFile)
+	if os.IsNotExist(err) {
+		return defaultGenesisConfig, nil
+	}
+	if err != nil {
+		return GenesisConfig{}, err
+	}
+
+	cfg := defaultGenesisConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return GenesisConfig{}, fmt.Errorf("%s: %w", genesisConfigFile, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return GenesisConfig{}, fmt.Errorf("%s: %w", genesisConfigFile, err)
+	}
+	return cfg, nil
+}
+
+// saveGenesisConfig writes genesis.json next to the block files
+func saveGenesisConfig(store BlockStore, cfg GenesisConfig) error {
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return store.WriteFile(genesisConfigFile, append(data, '\n'), 0644)
+}
+
+// maxRetargetHistory bounds how many past blocks are given to the retarget algorithm
+const maxRetargetHistory = 4096
+
+// nextDifficulty returns the difficulty the retarget algorithm requires for the block after
+// blockchain; ok is false when the chain uses manual difficulty
+func nextDifficulty(cfg GenesisConfig, blockchain []Block) (difficulty int, ok bool) {
+	if cfg.Retarget == retargetManual || len(blockchain) == 0 {
+		return 0, false
+	}
+	retargeter, err := newRetargeter(cfg.Retarget)
+	if err != nil {
+		return 0, false
+	}
+
+	start := len(blockchain) - maxRetargetHistory
+	if start < 0 {
+		start = 0
+	}
+	history := make([]retargetSample, 0, len(blockchain)-start)
+	var lastTime float64
+	for _, block := range blockchain[start:] {
+		if timestamp, err := time.Parse(time.RFC3339, block.Timestamp); err == nil {
+			lastTime = float64(timestamp.Unix())
+		}
+		history = append(history, retargetSample{Height: block.Index, Work: expectedHashes(block.Difficulty), Time: lastTime})
+	}
+
+	// Difficulty berupa jumlah nol hex, jadi work dibulatkan ke pangkat 16 terdekat
+	work := retargeter.NextWork(history, cfg.TargetInterval)
+	difficulty = int(math.Round(math.Log(work) / math.Log(16)))
+	if difficulty < 0 {
+		difficulty = 0
+	}
+	if difficulty > 64 {
+		difficulty = 64
+	}
+	return difficulty, true
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// hasherMessage is one line of the external hasher protocol. The node sends "work" and
+// "cancel"; the hasher answers with "progress", "solution", or "error". A solution is a nonce
+// such that pow(prefix + decimal(nonce) + suffix) starts with difficulty hex zeros.
+type hasherMessage struct {
+	Type       string `json:"type"`
+	ID         int    `json:"id"`
+	PoW        string `json:"pow,omitempty"`
+	Prefix     string `json:"prefix,omitempty"` // Hex: index + timestamp + data
+	Suffix     string `json:"suffix,omitempty"` // Hex: previous hash + extra data + digest evidence
+	Difficulty int    `json:"difficulty,omitempty"`
+	Nonce      uint64 `json:"nonce,omitempty"`
+	Hashes     uint64 `json:"hashes,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// externalHasher delegates nonce grinding to a helper process speaking JSON lines over stdin/stdout
+type externalHasher struct {
+	mu      sync.Mutex // Satu pekerjaan pada satu waktu
+	command string
+	stdin   io.WriteCloser
+	replies chan hasherMessage
+	nextID  int
+}
+
+// startExternalHasher starts command (split on spaces) and reads its replies in the background
+func startExternalHasher(command string) (*externalHasher, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("perintah hasher kosong")
+	}
+	cmd := exec.Command(fields[0], fields[1:]...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	h := &externalHasher{command: command, stdin: stdin, replies: make(chan hasherMessage, 16)}
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			var message hasherMessage
+			if json.Unmarshal(scanner.Bytes(), &message) == nil {
+				h.replies <- message
+			}
+		}
+		close(h.replies)
+		cmd.Wait()
+	}()
+	return h, nil
+}
+
+func (h *externalHasher) send(message hasherMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = h.stdin.Write(append(data, '\n'))
+	return err
+}
+
+// Close ends the hasher process by closing its stdin
+func (h *externalHasher) Close() error {
+	return h.stdin.Close()
+}
+
+// Mine has the external process grind nonces for the block after previousBlock; it mirrors mineBlock
+func (h *externalHasher) Mine(ctx context.Context, cfg GenesisConfig, data, extraData string, evidence []DoubleSignEvidence, previousBlock Block, difficulty int, observer MiningObserver) (Block, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Timestamp tetap selama satu unit kerja, karena hasher hanya mengganti nonce
+	block := Block{
+		Index:        previousBlock.Index + 1,
+		Timestamp:    time.Now().Format(time.RFC3339),
+		Data:         data,
+		PreviousHash: previousBlock.Hash,
+		Difficulty:   difficulty,
+		ExtraData:    extraData,
+		Evidence:     evidence,
+	}
+	fields := blockRecordFields(block)
+	var prefix, suffix []byte
+	for _, field := range fields[:3] {
+		prefix = append(prefix, field.Value...)
+	}
+	for _, field := range fields[4:] {
+		suffix = append(suffix, field.Value...)
+	}
+
+	h.nextID++
+	id := h.nextID
+	startTime := time.Now()
+	work := hasherMessage{Type: "work", ID: id, PoW: cfg.PoW, Prefix: hex.EncodeToString(prefix), Suffix: hex.EncodeToString(suffix), Difficulty: difficulty}
+	if err := h.send(work); err != nil {
+		return Block{}, fmt.Errorf("hasher %q: %w", h.command, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.send(hasherMessage{Type: "cancel", ID: id})
+			observer.MiningCancelled(time.Since(startTime))
+			return Block{}, ctx.Err()
+		case reply, ok := <-h.replies:
+			if !ok {
+				return Block{}, fmt.Errorf("hasher %q berhenti", h.command)
+			}
+			if reply.ID != id {
+				continue // Balasan untuk pekerjaan lama yang sudah dibatalkan
+			}
+			switch reply.Type {
+			case "progress":
+				observer.MiningProgress(reply.Hashes)
+			case "error":
+				return Block{}, fmt.Errorf("hasher %q: %s", h.command, reply.Error)
+			case "solution":
+				// Solusi dari proses luar tidak dipercaya begitu saja
+				block.Nonce = reply.Nonce
+				block.Hash = calculateHash(cfg, block)
+				if !strings.HasPrefix(block.Hash, strings.Repeat("0", difficulty)) {
+					return Block{}, fmt.Errorf("hasher %q mengirim nonce %d yang tidak memenuhi difficulty", h.command, reply.Nonce)
+				}
+				observer.MiningSolved(block, time.Since(startTime))
+				return block, nil
+			}
+		}
+	}
+}
+
+// runHasherCommand is a reference implementation of the external hasher protocol, usable as
+// -hasher "<program> hasher" and as a template for GPU helpers
+func runHasherCommand(store BlockStore, args []string) error {
+	encoder := json.NewEncoder(os.Stdout)
+	var encodeMu sync.Mutex
+	reply := func(message hasherMessage) {
+		encodeMu.Lock()
+		defer encodeMu.Unlock()
+		encoder.Encode(message)
+	}
+
+	var cancelMu sync.Mutex
+	cancels := make(map[int]context.CancelFunc)
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var message hasherMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			continue
+		}
+		switch message.Type {
+		case "cancel":
+			cancelMu.Lock()
+			if cancel, ok := cancels[message.ID]; ok {
+				cancel()
+			}
+			cancelMu.Unlock()
+		case "work":
+			ctx, cancel := context.WithCancel(context.Background())
+			cancelMu.Lock()
+			cancels[message.ID] = cancel
+			cancelMu.Unlock()
+			go func(work hasherMessage) {
+				defer cancel()
+				grindNonces(ctx, work, reply)
+			}(message)
+		}
+	}
+	return scanner.Err()
+}
+
+// grindNonces searches nonces for one work unit, reporting progress every million hashes
+func grindNonces(ctx context.Context, work hasherMessage, reply func(hasherMessage)) {
+	if work.PoW != powSHA256 {
+		reply(hasherMessage{Type: "error", ID: work.ID, Error: "hanya PoW " + powSHA256 + " yang didukung"})
+		return
+	}
+	prefix, err1 := hex.DecodeString(work.Prefix)
+	suffix, err2 := hex.DecodeString(work.Suffix)
+	if err1 != nil || err2 != nil || work.Difficulty < 0 || work.Difficulty > 64 {
+		reply(hasherMessage{Type: "error", ID: work.ID, Error: "unit kerja tidak valid"})
+		return
+	}
+	target := strings.Repeat("0", work.Difficulty)
+	for nonce := uint64(0); ; nonce++ {
+		if nonce%1000000 == 0 && nonce > 0 {
+			if ctx.Err() != nil {
+				return
+			}
+			reply(hasherMessage{Type: "progress", ID: work.ID, Hashes: nonce})
+		}
+		record := append(strconv.AppendUint(append([]byte(nil), prefix...), nonce, 10), suffix...)
+		sum := sha256.Sum256(record)
+		if strings.HasPrefix(hex.EncodeToString(sum[:]), target) {
+			reply(hasherMessage{Type: "solution", ID: work.ID, Nonce: nonce})
+			return
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxHealAttempts bounds how many corrupt blocks one heal run replaces
+const maxHealAttempts = 100
+
+// corruptBlockError reports a block file that cannot be read or decoded
+type corruptBlockError struct {
+	File  string
+	Index int
+	Err   error
+}
+
+func (e *corruptBlockError) Error() string { return fmt.Sprintf("%s: %v", e.File, e.Err) }
+func (e *corruptBlockError) Unwrap() error { return e.Err }
+
+// Block downloads the block with the given index
+func (c *remoteClient) Block(index int) (Block, error) {
+	var block Block
+	err := c.do(http.MethodGet, "/blocks/"+strconv.Itoa(index), nil, &block)
+	return block, err
+}
+
+// healPeers resolves -heal-from: a comma separated list of node URLs, or "auto" to use the
+// nodes found on the local network
+func healPeers(from string) ([]*remoteClient, error) {
+	var urls []string
+	if from == "auto" {
+		nodes, err := discoverNodes(discoveryInterval + time.Second)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			urls = append(urls, node.URL)
+		}
+	} else {
+		for _, url := range strings.Split(from, ",") {
+			if url = strings.TrimSpace(url); url != "" {
+				urls = append(urls, url)
+			}
+		}
+	}
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("tidak ada peer untuk memperbaiki blok")
+	}
+	peers := make([]*remoteClient, len(urls))
+	for i, url := range urls {
+		peers[i] = newRemoteClient(url, "")
+		peers[i].http.Timeout = 10 * time.Second
+	}
+	return peers, nil
+}
+
+// readStoredBlock decodes one block file of the store, or reports false when it is missing or corrupt
+func (s fileBlockStore) readStoredBlock(index int) (Block, bool) {
+	name := fmt.Sprintf("block%d.json", index)
+	data, err := s.ReadFile(name)
+	if err != nil {
+		return Block{}, false
+	}
+	if data, err = openRecord(s.cipher, name, data); err != nil {
+		return Block{}, false
+	}
+	block, err := decodeBlock(data)
+	return block, err == nil && block.Index == index
+}
+
+// firstBadBlock loads the chain and returns the index of the first block that is corrupt or
+// fails validation, or -1 when the whole chain is valid
+func (s fileBlockStore) firstBadBlock(cfg GenesisConfig) (int, error) {
+	blocks, err := s.LoadBlocks()
+	var corrupt *corruptBlockError
+	if errors.As(err, &corrupt) {
+		return corrupt.Index, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	for i := range blocks {
+		if blocks[i].Index != i {
+			return i, nil
+		}
+		if _, err := validateBlockAt(cfg, blocks, i, verifyStandard); err != nil {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+// fetchReplacement asks each peer for block index and returns the first copy that is valid
+// on its own and links to the local neighbours, so a peer can repair but not rewrite history
+func (s fileBlockStore) fetchReplacement(cfg GenesisConfig, index int, peers []*remoteClient) (Block, error) {
+	prev, hasPrev := s.readStoredBlock(index - 1)
+	next, hasNext := s.readStoredBlock(index + 1)
+	var lastErr error
+	for _, peer := range peers {
+		block, err := peer.Block(index)
+		switch {
+		case err != nil:
+			lastErr = fmt.Errorf("%s: %w", peer.baseURL, err)
+		case block.Index != index || block.Hash != calculateHash(cfg, block):
+			lastErr = fmt.Errorf("%s: blok %d dari peer tidak valid", peer.baseURL, index)
+		case !strings.HasPrefix(block.Hash, strings.Repeat("0", block.Difficulty)):
+			lastErr = fmt.Errorf("%s: blok %d dari peer tidak memenuhi difficulty", peer.baseURL, index)
+		case index > 0 && hasPrev && block.PreviousHash != prev.Hash:
+			lastErr = fmt.Errorf("%s: blok %d dari peer tidak menyambung ke blok %d lokal", peer.baseURL, index, index-1)
+		case hasNext && next.PreviousHash != block.Hash:
+			lastErr = fmt.Errorf("%s: blok %d dari peer tidak menyambung ke blok %d lokal", peer.baseURL, index, index+1)
+		default:
+			return block, nil
+		}
+	}
+	return Block{}, lastErr
+}
+
+// Heal replaces corrupt blocks with verified copies from peers until the chain, whose genesis
+// config is cfg, validates; the corrupt files are kept in archive/corrupt-<time> and the
+// repaired indexes are returned
+func (s fileBlockStore) Heal(cfg GenesisConfig, peers []*remoteClient) ([]int, error) {
+	defer startSpan("sync.heal")()
+	var repaired []int
+	archive := filepath.Join(s.dir, archiveDir, "corrupt-"+time.Now().Format("20060102-150405"))
+	for attempt := 0; attempt < maxHealAttempts; attempt++ {
+		index, err := s.firstBadBlock(cfg)
+		if err != nil || index < 0 {
+			return repaired, err
+		}
+		for _, done := range repaired {
+			if done == index {
+				return repaired, fmt.Errorf("blok %d masih tidak valid setelah diganti; chain lokal perlu diperiksa manual", index)
+			}
+		}
+
+		block, err := s.fetchReplacement(cfg, index, peers)
+		if err != nil {
+			return repaired, fmt.Errorf("blok %d rusak dan tidak dapat diperbaiki: %w", index, err)
+		}
+		name := fmt.Sprintf("block%d.json", index)
+		if err := os.MkdirAll(archive, os.ModePerm); err != nil {
+			return repaired, err
+		}
+		if err := copyStoreFile(filepath.Join(s.dir, name), filepath.Join(archive, name)); err != nil && !os.IsNotExist(err) {
+			return repaired, err
+		}
+		if err := s.SaveBlock(block); err != nil {
+			return repaired, err
+		}
+		fmt.Printf(Yellow+"Blok %d rusak, diganti dengan salinan terverifikasi dari peer.\n"+Reset, index)
+		repaired = append(repaired, index)
+	}
+	return repaired, fmt.Errorf("lebih dari %d blok rusak", maxHealAttempts)
+}
+
+// healOnStart repairs the local chain from the peers in from and reloads it, so a node with a
+// corrupt block keeps running instead of halting
+func healOnStart(store BlockStore, from string) ([]Block, error) {
+	files, ok := store.(fileBlockStore)
+	if !ok {
+		return nil, fmt.Errorf("-heal-from hanya tersedia untuk chain di disk yang dapat ditulis")
+	}
+	cfg, err := loadGenesisConfig(store)
+	if err != nil {
+		return nil, err
+	}
+	peers, err := healPeers(from)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := files.Heal(cfg, peers); err != nil {
+		return nil, err
+	}
+	return loadBlockchain(store)
+}
+
+// runHealCommand implements "heal -from url,url|auto"
+func runHealCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("heal", flag.ContinueOnError)
+	from := fs.String("from", "auto", "URL node peer dipisah koma, atau auto untuk node di jaringan lokal")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	files, ok := store.(fileBlockStore)
+	if !ok {
+		return fmt.Errorf("heal hanya tersedia untuk chain di disk yang dapat ditulis")
+	}
+	cfg, err := loadGenesisConfig(store)
+	if err != nil {
+		return err
+	}
+	peers, err := healPeers(*from)
+	if err != nil {
+		return err
+	}
+	repaired, err := files.Heal(cfg, peers)
+	if err != nil {
+		return err
+	}
+	if len(repaired) == 0 {
+		fmt.Println(Green + "Tidak ada blok rusak." + Reset)
+		return nil
+	}
+	fmt.Printf(Green+"%d blok diperbaiki: %v\n"+Reset, len(repaired), repaired)
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testPeer serves blockchain over the REST API and returns a client for it
+func testPeer(t *testing.T, blockchain []Block) *remoteClient {
+	t.Helper()
+	chain := newChain(fixtureGenesisConfig(), newMemoryBlockStore(), blockchain, 1)
+	server := httptest.NewServer(newAPIServer(chain, nil, nil, nil).routes())
+	t.Cleanup(server.Close)
+	return newRemoteClient(server.URL, "")
+}
+
+func TestHeal(t *testing.T) {
+	cfg := fixtureGenesisConfig()
+	blockchain := fixtureChain(cfg, 1, 5, 1)
+
+	// Fork menyambung ke blok 1 lokal, tetapi blok 3 lokal tidak menyambung ke blok 2 fork
+	fork := append([]Block(nil), blockchain[:2]...)
+	fork = append(fork, mineFixtureBlock(cfg, 2, fixtureEpoch.Add(time.Hour), "fork", blockchain[1].Hash, 1))
+	forged := append([]Block(nil), blockchain...)
+	forged[2].Data = "dipalsukan" // Hash tidak lagi cocok dengan isi blok
+
+	tests := []struct {
+		name    string
+		peers   [][]Block
+		wantErr string // Kosong berarti blok 2 harus diperbaiki
+	}{
+		{name: "honest peer", peers: [][]Block{blockchain}},
+		{name: "fork peer", peers: [][]Block{fork}, wantErr: "tidak menyambung ke blok 3 lokal"},
+		{name: "forged block", peers: [][]Block{forged}, wantErr: "tidak valid"},
+		{name: "peer without the block", peers: [][]Block{blockchain[:2]}, wantErr: "blok 2 rusak"},
+		{name: "fork peer before honest peer", peers: [][]Block{fork, forged, blockchain}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := testStore(t, blockchain)
+			corrupt := []byte(`{"index": 2, "data": "terpot`)
+			if err := os.WriteFile(filepath.Join(store.dir, "block2.json"), corrupt, 0644); err != nil {
+				t.Fatal(err)
+			}
+			var peers []*remoteClient
+			for _, chain := range tt.peers {
+				peers = append(peers, testPeer(t, chain))
+			}
+
+			repaired, err := store.Heal(cfg, peers)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Heal error = %v, want %q", err, tt.wantErr)
+				}
+				if data, _ := os.ReadFile(filepath.Join(store.dir, "block2.json")); string(data) != string(corrupt) {
+					t.Errorf("corrupt block replaced although no peer had a valid copy")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Heal: %v", err)
+			}
+			if !reflect.DeepEqual(repaired, []int{2}) {
+				t.Errorf("repaired = %v, want [2]", repaired)
+			}
+			if got, want := blockHashes(t, store), blockHashes(t, testStore(t, blockchain)); !reflect.DeepEqual(got, want) {
+				t.Errorf("blocks after heal = %v, want %v", got, want)
+			}
+			archived, _ := filepath.Glob(filepath.Join(store.dir, archiveDir, "corrupt-*", "block2.json"))
+			if len(archived) != 1 {
+				t.Fatalf("corrupt block archived %d times, want once", len(archived))
+			}
+			if data, _ := os.ReadFile(archived[0]); string(data) != string(corrupt) {
+				t.Errorf("archived block2.json = %q, want the corrupt file", data)
+			}
+		})
+	}
+}
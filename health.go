@@ -0,0 +1,216 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Batas ruang disk yang dilaporkan oleh doctor
+const (
+	diskSpaceWarning = 100 << 20
+	diskSpaceFailure = 10 << 20
+)
+
+// Hasil pemeriksaan kesehatan
+const (
+	checkOK   = "ok"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// healthCheck is the result of one readiness or doctor check
+type healthCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// readinessChecks reports whether the node can serve: storage reachable, the chain linked
+// and hashed correctly up to the tip, and the mining queue below its limit. The simulation
+// has no peer connections or mempool; the mining queue is the closest thing to the latter.
+func readinessChecks(chain *Chain, queue *miningQueue) []healthCheck {
+	var checks []healthCheck
+
+	if _, err := chain.Store().ReadFile(genesisConfigFile); err != nil && !os.IsNotExist(err) {
+		checks = append(checks, healthCheck{"storage", checkFail, err.Error()})
+	} else {
+		checks = append(checks, healthCheck{"storage", checkOK, "penyimpanan dapat dibaca"})
+	}
+
+	blocks := chain.Blocks()
+	if _, rule, err := verifyChain(chain.Config(), blocks, verifyQuick); err != nil {
+		checks = append(checks, healthCheck{"chain", checkFail, fmt.Sprintf("[%s] %v", rule, err)})
+	} else {
+		checks = append(checks, healthCheck{"chain", checkOK, fmt.Sprintf("%d blok valid sampai tip %s", len(blocks), blocks[len(blocks)-1].Hash)})
+	}
+
+	if queue != nil {
+		pending := len(queue.pending)
+		switch {
+		case pending >= maxQueuedJobs:
+			checks = append(checks, healthCheck{"queue", checkFail, fmt.Sprintf("antrian mining penuh (%d pekerjaan)", pending)})
+		case pending >= maxQueuedJobs*9/10:
+			checks = append(checks, healthCheck{"queue", checkWarn, fmt.Sprintf("antrian mining hampir penuh (%d dari %d)", pending, maxQueuedJobs)})
+		default:
+			checks = append(checks, healthCheck{"queue", checkOK, fmt.Sprintf("%d dari %d pekerjaan menunggu", pending, maxQueuedJobs)})
+		}
+	}
+	return checks
+}
+
+// handleHealthz reports that the process is alive
+func (s *apiServer) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": checkOK})
+}
+
+// handleReadyz runs the readiness checks and answers 503 when one of them fails
+func (s *apiServer) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	queue := s.queue
+	if s.readOnly {
+		queue = nil
+	}
+	checks := readinessChecks(s.chain, queue)
+	status, code := checkOK, http.StatusOK
+	for _, check := range checks {
+		if check.Status == checkFail {
+			status, code = checkFail, http.StatusServiceUnavailable
+		}
+	}
+	writeJSON(w, code, map[string]any{"status": status, "checks": checks})
+}
+
+// doctorChecks inspects the data directory, disk space, configuration, chain and clock of store
+func doctorChecks(store BlockStore, peer string) []healthCheck {
+	var checks []healthCheck
+	add := func(name, status, format string, args ...any) {
+		checks = append(checks, healthCheck{name, status, fmt.Sprintf(format, args...)})
+	}
+
+	// Direktori data dan ruang disk
+	if files, ok := onDiskStore(store); ok {
+		_, readOnly := store.(readOnlyBlockStore)
+		probe := filepath.Join(files.dir, ".doctor")
+		switch {
+		case readOnly:
+			add("datadir", checkOK, "%s dibuka read-only", files.dir)
+		case os.MkdirAll(files.dir, os.ModePerm) != nil:
+			add("datadir", checkFail, "%s tidak dapat dibuat; periksa permission direktori induk", files.dir)
+		case os.WriteFile(probe, nil, 0644) != nil:
+			add("datadir", checkFail, "%s tidak dapat ditulis; periksa pemilik dan permission direktori", files.dir)
+		default:
+			os.Remove(probe)
+			add("datadir", checkOK, "%s dapat ditulis", files.dir)
+		}
+
+		if free, err := freeDiskSpace(files.dir); err != nil {
+			add("disk", checkWarn, "ruang disk tidak dapat diperiksa: %v", err)
+		} else if free < diskSpaceFailure {
+			add("disk", checkFail, "hanya %s tersisa; kosongkan disk atau pindahkan -datadir", formatBytes(int64(free)))
+		} else if free < diskSpaceWarning {
+			add("disk", checkWarn, "hanya %s tersisa; jalankan db compact atau hapus snapshot lama", formatBytes(int64(free)))
+		} else {
+			add("disk", checkOK, "%s tersisa", formatBytes(int64(free)))
+		}
+
+		if temps, _ := filepath.Glob(filepath.Join(files.dir, "*.tmp")); len(temps) > 0 {
+			add("temp", checkWarn, "%d file sementara dari penulisan yang terputus; jalankan db compact", len(temps))
+		}
+	} else {
+		add("datadir", checkOK, "chain disimpan di memori (-ephemeral)")
+	}
+
+	// Konfigurasi genesis
+	cfg, err := loadGenesisConfig(store)
+	if err != nil {
+		add("config", checkFail, "%v; perbaiki atau hapus %s", err, genesisConfigFile)
+		return checks
+	}
+	add("config", checkOK, "PoW %s, konsensus %s, retarget %s", cfg.PoW, cfg.Consensus, cfg.Retarget)
+
+	// Blockchain
+	blockchain, err := loadBlockchain(store)
+	switch {
+	case err != nil:
+		add("chain", checkFail, "%v; coba heal -from <url> atau restore dari backup", err)
+		return checks
+	case len(blockchain) == 0:
+		add("chain", checkOK, "belum ada blok; blok genesis dibuat saat node pertama kali dijalankan")
+		return checks
+	}
+	if _, rule, err := verifyChain(cfg, blockchain, verifyStandard); err != nil {
+		add("chain", checkFail, "[%s] %v; coba heal -from <url>, rollback, atau restore", rule, err)
+	} else {
+		add("chain", checkOK, "%d blok valid", len(blockchain))
+	}
+	if _, err := store.ReadFile(miningStateFile); err == nil {
+		add("mining", checkWarn, "ada pencarian nonce yang terputus; akan dilanjutkan saat node dijalankan")
+	}
+
+	// Jam: blok dari masa depan berarti jam lokal terlambat
+	tip := blockchain[len(blockchain)-1]
+	if timestamp, err := time.Parse(time.RFC3339, tip.Timestamp); err == nil {
+		if ahead := time.Until(timestamp); ahead > cfg.maxFutureBlockTime() {
+			add("clock", checkFail, "tip %v di masa depan, melebihi batas %v; sinkronkan jam sistem", ahead.Round(time.Second), cfg.maxFutureBlockTime())
+		} else if ahead > 0 {
+			add("clock", checkWarn, "tip %v di masa depan; jam sistem mungkin terlambat", ahead.Round(time.Second))
+		}
+	}
+	if peer != "" {
+		if skew, err := clockSkew(peer); err != nil {
+			add("clock", checkWarn, "jam %s tidak dapat dibaca: %v", peer, err)
+		} else if skew > 2*time.Second || skew < -2*time.Second {
+			add("clock", checkWarn, "jam lokal berbeda %v dari %s; sinkronkan jam sistem (NTP)", skew.Round(time.Second), peer)
+		} else {
+			add("clock", checkOK, "jam lokal selisih %v dari %s", skew.Round(time.Millisecond), peer)
+		}
+	}
+	return checks
+}
+
+// clockSkew compares the local clock with the Date header of a node or any HTTP server
+func clockSkew(url string) (time.Duration, error) {
+	client := &http.Client{Timeout: 5 * time.Second}
+	before := time.Now()
+	resp, err := client.Head(url)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	remote, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return 0, fmt.Errorf("header Date tidak ada")
+	}
+	// Header Date dibulatkan ke detik; bandingkan dengan titik tengah request
+	local := before.Add(time.Since(before) / 2)
+	return local.Sub(remote), nil
+}
+
+// runDoctorCommand implements "doctor [-peer url]"
+func runDoctorCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	peer := fs.String("peer", "", "URL node atau server HTTP untuk memeriksa selisih jam")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, check := range doctorChecks(store, *peer) {
+		label := Green + "OK   " + Reset
+		switch check.Status {
+		case checkWarn:
+			label = Yellow + "WARN " + Reset
+		case checkFail:
+			label = Red + "FAIL " + Reset
+			failed++
+		}
+		fmt.Printf("%s %-8s %s\n", label, check.Name, check.Message)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d pemeriksaan gagal", failed)
+	}
+	return nil
+}
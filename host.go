@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"regexp"
+)
+
+// hostedChain is one entry of the host configuration file
+type hostedChain struct {
+	Name    string   `json:"name"`    // Prefix API: /<name>/
+	DataDir string   `json:"datadir"` // Direktori blok chain ini
+	Args    []string `json:"args"`    // Flag tambahan, misalnya ["-genesis-difficulty", "3", "-api-keys", "team1.json"]
+}
+
+// hostedChainName restricts chain names to something safe in a URL path
+var hostedChainName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// loadHostedChains reads a JSON array of {"name", "datadir", "args"} objects
+func loadHostedChains(path string) ([]*hostedChain, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var chains []*hostedChain
+	if err := json.Unmarshal(data, &chains); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(chains) == 0 {
+		return nil, fmt.Errorf("%s: tidak ada chain", path)
+	}
+	names := make(map[string]bool)
+	dirs := make(map[string]bool)
+	for _, chain := range chains {
+		if !hostedChainName.MatchString(chain.Name) {
+			return nil, fmt.Errorf("%s: nama chain %q hanya boleh berisi huruf, angka, - dan _", path, chain.Name)
+		}
+		if chain.DataDir == "" {
+			chain.DataDir = chain.Name
+		}
+		if names[chain.Name] || dirs[chain.DataDir] {
+			return nil, fmt.Errorf("%s: nama atau datadir chain %q digunakan lebih dari sekali", path, chain.Name)
+		}
+		names[chain.Name] = true
+		dirs[chain.DataDir] = true
+	}
+	return chains, nil
+}
+
+// open loads the chain from its datadir, creating the genesis block on first start, and
+// prepares a headless node for it; Args accepts the node flags that make sense per chain
+func (c *hostedChain) open() (*Node, error) {
+	fs := flag.NewFlagSet("chain "+c.Name, flag.ContinueOnError)
+	genesisDifficulty := fs.Int("genesis-difficulty", 5, "Tingkat kesulitan awal untuk chain baru")
+	retargetName := fs.String("retarget", retargetManual, "Algoritma retarget untuk chain baru")
+	targetInterval := fs.Float64("target-interval", defaultGenesisConfig.TargetInterval, "Target interval blok dalam detik untuk chain baru")
+	powName := fs.String("pow", powSHA256, "Algoritma proof-of-work untuk chain baru")
+	powMemory := fs.Int("pow-memory", defaultPoWMemoryKiB, "Ukuran scratchpad PoW memhard dalam KiB untuk chain baru")
+	maxDataSize := fs.Int("max-data-size", 0, "Ukuran maksimum Data blok dalam byte untuk chain baru")
+	medianTimeSpan := fs.Int("median-time-span", defaultMedianTimeSpan, "Jumlah blok median time past untuk chain baru")
+	storageKeyFile := fs.String("storage-key-file", "", "File berisi passphrase enkripsi blok chain ini")
+	apiKeysPath := fs.String("api-keys", "", "File JSON berisi API key chain ini")
+	apiRate := fs.Float64("api-rate", 0, "Batas request REST API per detik per IP")
+	apiBurst := fs.Int("api-burst", 10, "Jumlah request beruntun sebelum rate limit berlaku")
+	extraData := fs.String("extra-data", "", "Pesan miner di setiap blok chain ini")
+	if err := fs.Parse(c.Args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("argumen tidak dikenal: %v", fs.Args())
+	}
+	if *genesisDifficulty < 0 || *genesisDifficulty > 64 {
+		return nil, fmt.Errorf("tingkat kesulitan awal harus antara 0 dan 64")
+	}
+
+	store := fileBlockStore{dir: c.DataDir}
+	passphrase, err := loadStorageKey(*storageKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if passphrase != "" {
+		if store.cipher, err = newStorageCipher(store, passphrase, true); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg, blockchain, err := loadChain(store)
+	if err != nil {
+		return nil, err
+	}
+	difficulty := *genesisDifficulty
+	if len(blockchain) == 0 {
+		cfg = defaultGenesisConfig
+		cfg.Retarget = *retargetName
+		cfg.TargetInterval = *targetInterval
+		cfg.PoW = *powName
+		cfg.PoWMemoryKiB = *powMemory
+		cfg.MaxDataSize = *maxDataSize
+		cfg.MedianTimeSpan = *medianTimeSpan
+		if err := cfg.validate(); err != nil {
+			return nil, err
+		}
+		if err := saveGenesisConfig(store, cfg); err != nil {
+			return nil, err
+		}
+		genesisBlock := createGenesisBlock(cfg, difficulty)
+		if err := saveBlock(store, genesisBlock); err != nil {
+			return nil, err
+		}
+		blockchain = append(blockchain, genesisBlock)
+	} else {
+		difficulty = blockchain[len(blockchain)-1].Difficulty
+		if next, ok := nextDifficulty(cfg, blockchain); ok {
+			difficulty = next
+		}
+	}
+
+	config := NodeConfig{ExtraData: *extraData, APIRate: *apiRate, APIBurst: *apiBurst}
+	if *apiKeysPath != "" {
+		if config.APIKeys, err = loadAPIKeys(*apiKeysPath); err != nil {
+			return nil, err
+		}
+	}
+	return NewNode(newChain(cfg, store, blockchain, difficulty), config)
+}
+
+// runHostCommand implements "host [-addr :8080] <chains.json>". Every chain runs as its own
+// node in this process, with its own datadir and genesis parameters; one HTTP server serves
+// /<name>/... from the REST API of that chain.
+func runHostCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("host", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "Alamat HTTP bersama untuk semua chain")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("penggunaan: host [-addr :8080] <chains.json>")
+	}
+	chains, err := loadHostedChains(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var nodes []*Node
+	defer func() {
+		for _, node := range nodes {
+			node.Stop()
+		}
+	}()
+	mux := http.NewServeMux()
+	for _, chain := range chains {
+		node, err := chain.open()
+		if err != nil {
+			return fmt.Errorf("chain %s: %w", chain.Name, err)
+		}
+		if err := node.Start(ctx); err != nil {
+			return fmt.Errorf("chain %s: %w", chain.Name, err)
+		}
+		nodes = append(nodes, node)
+
+		mux.Handle("/"+chain.Name+"/", http.StripPrefix("/"+chain.Name, node.Handler()))
+		fmt.Printf(Green+"Chain %s (%s, %d blok) tersedia di /%s/\n"+Reset, chain.Name, chain.DataDir, node.Chain.Len(), chain.Name)
+	}
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		type chainInfo struct {
+			Name string `json:"name"`
+			Path string `json:"path"`
+		}
+		infos := make([]chainInfo, 0, len(chains))
+		for _, chain := range chains {
+			infos = append(infos, chainInfo{Name: chain.Name, Path: "/" + chain.Name + "/"})
+		}
+		writeJSON(w, http.StatusOK, infos)
+	})
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: mux}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	fmt.Printf(BoldYellow+"Host berjalan di %s dengan %d chain. Tekan Ctrl+C untuk berhenti.\n"+Reset, *addr, len(chains))
+	if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		fmt.Println(Red+"Error host:"+Reset, err)
+	}
+	return nil
+}
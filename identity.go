@@ -0,0 +1,131 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// nodeKeyFile stores the node identity key in the blocks directory, next to genesis.json
+const nodeKeyFile = "node.key"
+
+// nodeIdentity is the persistent keypair that identifies a node independently of its address
+type nodeIdentity struct {
+	ID  string
+	key ed25519.PrivateKey
+}
+
+// nodeIDFromPublicKey derives the node ID: the first 20 bytes of SHA-256 of the public key, in hex
+func nodeIDFromPublicKey(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:20])
+}
+
+// loadNodeIdentity reads node.key from store, generating and saving a new key on first start
+func loadNodeIdentity(store BlockStore) (*nodeIdentity, error) {
+	path := nodeKeyFile
+	var keyFile struct {
+		PrivateKey string `json:"private_key"` // Seed ed25519 dalam hex
+	}
+
+	data, err := store.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		keyFile.PrivateKey = hex.EncodeToString(key.Seed())
+		data, err := json.MarshalIndent(keyFile, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		// Pada penyimpanan read-only identitas hanya berlaku selama proses berjalan
+		if err := store.WriteFile(path, append(data, '\n'), 0600); err != nil && !errors.Is(err, errReadOnlyStore) {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(data, &keyFile); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	seed, err := hex.DecodeString(keyFile.PrivateKey)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%s: private_key harus berupa seed ed25519 %d byte dalam hex", path, ed25519.SeedSize)
+	}
+	key := ed25519.NewKeyFromSeed(seed)
+	return &nodeIdentity{ID: nodeIDFromPublicKey(key.Public().(ed25519.PublicKey)), key: key}, nil
+}
+
+// PublicKey returns the hex-encoded public key of the node
+func (n *nodeIdentity) PublicKey() string {
+	return hex.EncodeToString(n.key.Public().(ed25519.PublicKey))
+}
+
+// Sign signs message with the node key and returns the hex-encoded signature
+func (n *nodeIdentity) Sign(message []byte) string {
+	return hex.EncodeToString(ed25519.Sign(n.key, message))
+}
+
+// verifyNodeSignature checks that publicKey belongs to nodeID and signed message
+func verifyNodeSignature(nodeID, publicKeyHex string, message []byte, signatureHex string) error {
+	publicKey, err := hex.DecodeString(publicKeyHex)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("public key node tidak valid")
+	}
+	if nodeIDFromPublicKey(publicKey) != nodeID {
+		return fmt.Errorf("public key tidak cocok dengan node ID %s", nodeID)
+	}
+	signature, err := hex.DecodeString(signatureHex)
+	if err != nil || !ed25519.Verify(publicKey, message, signature) {
+		return fmt.Errorf("tanda tangan node %s tidak valid", nodeID)
+	}
+	return nil
+}
+
+// identityChallengeMessage is what a node signs to prove its identity for a client challenge
+func identityChallengeMessage(challenge string) []byte {
+	return []byte("node-identity:" + challenge)
+}
+
+// handleNodeIdentity returns the node ID and public key; with ?challenge=<random hex> it also
+// signs the challenge so the client can authenticate the node it is talking to
+func (s *apiServer) handleNodeIdentity(w http.ResponseWriter, r *http.Request) {
+	response := map[string]string{"id": s.identity.ID, "public_key": s.identity.PublicKey()}
+	if challenge := r.URL.Query().Get("challenge"); challenge != "" {
+		response["signature"] = s.identity.Sign(identityChallengeMessage(challenge))
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// VerifyIdentity runs the challenge handshake against the remote node and checks that it holds
+// the key of the expected node ID
+func (c *remoteClient) VerifyIdentity(expectedID string) error {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	challenge := hex.EncodeToString(nonce)
+
+	var response struct {
+		ID        string `json:"id"`
+		PublicKey string `json:"public_key"`
+		Signature string `json:"signature"`
+	}
+	if err := c.do(http.MethodGet, "/node?challenge="+challenge, nil, &response); err != nil {
+		return err
+	}
+	if response.ID != expectedID {
+		return fmt.Errorf("node remote memiliki ID %s, bukan %s", response.ID, expectedID)
+	}
+	return verifyNodeSignature(response.ID, response.PublicKey, identityChallengeMessage(challenge), response.Signature)
+}
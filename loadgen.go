@@ -0,0 +1,195 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// loadgenResult is the outcome of one generated block request
+type loadgenResult struct {
+	sender  int
+	status  string // Status akhir pekerjaan, atau "rejected" jika ditolak node
+	reason  string
+	latency time.Duration // Dari pengiriman sampai pekerjaan selesai
+}
+
+// loadgenDataSize samples a data size from the configured distribution around mean bytes
+func loadgenDataSize(rng *rand.Rand, dist string, mean int) int {
+	switch dist {
+	case "uniform":
+		return rng.Intn(2*mean + 1)
+	case "exp":
+		return int(rng.ExpFloat64() * float64(mean))
+	default:
+		return mean
+	}
+}
+
+// loadgenData builds the data of one request: the sender and sequence number, padded to size
+func loadgenData(sender, seq, size int) string {
+	data := fmt.Sprintf("loadgen sender-%d #%d", sender, seq)
+	if len(data) < size {
+		data += " " + strings.Repeat("x", size-len(data)-1)
+	}
+	return data
+}
+
+// runLoadgenCommand implements "loadgen [-node url] [-rate R] [-duration D] [-size N] [-size-dist fixed|uniform|exp] [-senders S] [-zipf s]":
+// it sends block requests to the mining queue of a node at a steady rate and reports how the
+// node kept up. The chain has no transactions or mempool, so the queue is the load target.
+func runLoadgenCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("loadgen", flag.ContinueOnError)
+	nodeURL := fs.String("node", "http://localhost:8080", "URL REST API node yang diuji")
+	key := fs.String("key", "", "Secret API key dengan permission mine")
+	rate := fs.Float64("rate", 2, "Permintaan blok per detik")
+	duration := fs.Duration("duration", 30*time.Second, "Lama pengiriman permintaan")
+	drain := fs.Duration("drain", 2*time.Minute, "Waktu maksimum menunggu pekerjaan yang masih berjalan setelah pengiriman selesai")
+	size := fs.Int("size", 64, "Rata-rata ukuran data blok dalam byte")
+	sizeDist := fs.String("size-dist", "fixed", "Distribusi ukuran data: fixed, uniform, atau exp")
+	senders := fs.Int("senders", 20, "Jumlah pengirim simulasi")
+	zipf := fs.Float64("zipf", 0, "Parameter Zipf (> 1) agar sebagian kecil pengirim mengirim sebagian besar permintaan; 0 berarti merata")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "Seed generator acak")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *rate <= 0 || *duration <= 0 || *size < 0 || *senders < 1 {
+		return fmt.Errorf("rate dan duration harus > 0, size >= 0, dan senders >= 1")
+	}
+	if *sizeDist != "fixed" && *sizeDist != "uniform" && *sizeDist != "exp" {
+		return fmt.Errorf("distribusi ukuran tidak dikenal: %q (gunakan fixed, uniform, atau exp)", *sizeDist)
+	}
+	if *zipf != 0 && *zipf <= 1 {
+		return fmt.Errorf("parameter Zipf harus lebih dari 1")
+	}
+
+	client := newRemoteClient(*nodeURL, *key)
+	if _, err := client.Difficulty(); err != nil {
+		return fmt.Errorf("node %s tidak dapat dihubungi: %w", *nodeURL, err)
+	}
+	rng := rand.New(rand.NewSource(*seed))
+	var pickSender func() int
+	if *zipf > 1 {
+		z := rand.NewZipf(rng, *zipf, 1, uint64(*senders-1))
+		pickSender = func() int { return int(z.Uint64()) }
+	} else {
+		pickSender = func() int { return rng.Intn(*senders) }
+	}
+
+	fmt.Printf(BoldYellow+"Loadgen: %.1f permintaan/detik selama %v ke %s\n"+Reset, *rate, *duration, *nodeURL)
+	var mu sync.Mutex
+	var results []loadgenResult
+	record := func(result loadgenResult) {
+		mu.Lock()
+		results = append(results, result)
+		mu.Unlock()
+	}
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / *rate))
+	stop := time.After(*duration)
+	start := time.Now()
+	sent := 0
+submit:
+	for {
+		select {
+		case <-stop:
+			break submit
+		case <-ticker.C:
+		}
+		sender := pickSender()
+		data := loadgenData(sender, sent, loadgenDataSize(rng, *sizeDist, *size))
+		sent++
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			submitted := time.Now()
+			var job miningJob
+			if err := client.do(http.MethodPost, "/jobs", map[string]string{"data": data}, &job); err != nil {
+				record(loadgenResult{sender: sender, status: "rejected", reason: err.Error()})
+				return
+			}
+			if err := client.do(http.MethodGet, fmt.Sprintf("/jobs/%d?wait=true", job.ID), nil, &job); err != nil {
+				record(loadgenResult{sender: sender, status: "unknown", reason: err.Error()})
+				return
+			}
+			record(loadgenResult{sender: sender, status: job.Status, reason: job.Error, latency: time.Since(submitted)})
+		}()
+	}
+	ticker.Stop()
+	sendTime := time.Since(start)
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	fmt.Printf("%d permintaan dikirim; menunggu pekerjaan selesai (maksimal %v)...\n", sent, *drain)
+	select {
+	case <-done:
+	case <-time.After(*drain):
+		fmt.Println(Yellow + "Waktu tunggu habis, sebagian pekerjaan belum selesai." + Reset)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	printLoadgenReport(results, sent, *senders, sendTime, time.Since(start))
+	return nil
+}
+
+// printLoadgenReport summarizes the outcomes, the latency of finished jobs and the hot senders
+func printLoadgenReport(results []loadgenResult, sent, senders int, sendTime, total time.Duration) {
+	statuses := make(map[string]int)
+	reasons := make(map[string]int)
+	perSender := make([]int, senders)
+	var latencies []time.Duration
+	for _, result := range results {
+		statuses[result.status]++
+		perSender[result.sender]++
+		if result.status == "rejected" {
+			reasons[result.reason]++
+		}
+		if result.status == jobDone {
+			latencies = append(latencies, result.latency)
+		}
+	}
+
+	fmt.Println(BoldYellow + "\n=== Hasil Loadgen ===" + Reset)
+	fmt.Printf("%sDikirim          :%s %d dalam %v (%.2f/detik)\n", BoldCyan, Reset, sent, sendTime.Round(time.Millisecond), float64(sent)/sendTime.Seconds())
+	fmt.Printf("%sSelesai (done)   :%s %d (%.2f blok/detik selama %v)\n", BoldCyan, Reset, statuses[jobDone], float64(statuses[jobDone])/total.Seconds(), total.Round(time.Millisecond))
+	fmt.Printf("%sGagal/dibatalkan :%s %d / %d\n", BoldCyan, Reset, statuses[jobFailed], statuses[jobCancelled])
+	fmt.Printf("%sDitolak node     :%s %d\n", BoldCyan, Reset, statuses["rejected"])
+	for reason, count := range reasons {
+		fmt.Printf("  %4d  %s\n", count, reason)
+	}
+	if unfinished := sent - len(results) + statuses["unknown"]; unfinished > 0 {
+		fmt.Printf("%sBelum selesai    :%s %d\n", BoldCyan, Reset, unfinished)
+	}
+
+	if len(latencies) > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		percentile := func(p float64) time.Duration {
+			return latencies[int(p*float64(len(latencies)-1))].Round(time.Millisecond)
+		}
+		fmt.Printf("%sLatensi P50/P90/P99/Max:%s %v / %v / %v / %v\n", BoldCyan, Reset, percentile(0.5), percentile(0.9), percentile(0.99), latencies[len(latencies)-1].Round(time.Millisecond))
+	}
+
+	// Pengirim teratas menunjukkan seberapa terpusat lalu lintasnya
+	order := make([]int, senders)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return perSender[order[i]] > perSender[order[j]] })
+	fmt.Printf("%sPengirim teratas:%s", BoldCyan, Reset)
+	for _, sender := range order[:min(5, senders)] {
+		if perSender[sender] > 0 && len(results) > 0 {
+			fmt.Printf(" sender-%d %.0f%%", sender, 100*float64(perSender[sender])/float64(len(results)))
+		}
+	}
+	fmt.Println()
+}
@@ -1,392 +1,817 @@
-package main
-
-import (
-	"bufio"
-	"crypto/sha256"
-	"encoding/hex"
-	"encoding/json"
-	"fmt"
-	"os"
-	"path/filepath"
-	"runtime"
-	"sort"
-	"strconv"
-	"strings"
-	"sync"
-	"time"
-)
-
-// ANSI escape codes for coloring
-const (
-	Reset      = "\033[0m"
-	Bold       = "\033[1m"
-	Red        = "\033[31m"
-	Green      = "\033[32m"
-	Yellow     = "\033[33m"
-	Blue       = "\033[34m"
-	Magenta    = "\033[35m"
-	Cyan       = "\033[36m"
-	BoldYellow = "\033[1;33m"
-	BoldCyan   = "\033[1;36m"
-	BoldGreen  = "\033[1;32m"
-	BoldRed    = "\033[1;31m"
-	BoldBlue   = "\033[1;34m" // Menambahkan definisi BoldBlue
-)
-
-// Block represents each block in the blockchain
-type Block struct {
-	Index        int    `json:"index"`
-	Timestamp    string `json:"timestamp"`
-	Data         string `json:"data"`
-	Nonce        uint64 `json:"nonce"`
-	Hash         string `json:"hash"`
-	PreviousHash string `json:"previous_hash"`
-	Difficulty   int    `json:"difficulty"` // **Field Difficulty ditambahkan**
-}
-
-// calculateHash calculates the SHA-256 hash of a block's contents
-func calculateHash(block Block) string {
-	record := strconv.Itoa(block.Index) + block.Timestamp + block.Data + strconv.FormatUint(block.Nonce, 10) + block.PreviousHash
-	hash := sha256.New()
-	hash.Write([]byte(record))
-	return hex.EncodeToString(hash.Sum(nil))
-}
-
-// createGenesisBlock creates the first block in the blockchain by mining it with default difficulty
-func createGenesisBlock(difficulty int) Block {
-	fmt.Println(BoldYellow + "Membuat blok genesis melalui proses mining..." + Reset)
-
-	// Blok Dummy dengan Index=-1 dan PreviousHash=64 nol
-	dummyBlock := Block{
-		Index:        -1,
-		Timestamp:    "",
-		Data:         "",
-		Nonce:        0,
-		Hash:         "0000000000000000000000000000000000000000000000000000000000000000",
-		PreviousHash: "",
-	}
-
-	// Mine Genesis Block dengan menggunakan dummyBlock sebagai previousBlock
-	genesisBlock := mineBlock("Genesis Block", dummyBlock, difficulty)
-	return genesisBlock
-}
-
-// saveBlock saves a block as a JSON file
-func saveBlock(block Block) error {
-	// Pastikan direktori "blocks" ada
-	if _, err := os.Stat("blocks"); os.IsNotExist(err) {
-		err := os.Mkdir("blocks", os.ModePerm)
-		if err != nil {
-			return err
-		}
-	}
-
-	filename := fmt.Sprintf("block%d.json", block.Index)
-	filePath := filepath.Join("blocks", filename)
-	file, err := os.Create(filePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(block)
-}
-
-// loadBlockchain loads the blockchain from JSON files
-func loadBlockchain() ([]Block, error) {
-	var blockchain []Block
-
-	// Pastikan direktori "blocks" ada
-	if _, err := os.Stat("blocks"); os.IsNotExist(err) {
-		return blockchain, nil // Tidak ada blok yang disimpan
-	}
-
-	files, err := filepath.Glob("blocks/block*.json")
-	if err != nil {
-		return blockchain, err
-	}
-
-	// Sort files berdasarkan index
-	sort.Slice(files, func(i, j int) bool {
-		var indexI, indexJ int
-		fmt.Sscanf(filepath.Base(files[i]), "block%d.json", &indexI)
-		fmt.Sscanf(filepath.Base(files[j]), "block%d.json", &indexJ)
-		return indexI < indexJ
-	})
-
-	for _, file := range files {
-		var block Block
-		f, err := os.Open(file)
-		if err != nil {
-			return blockchain, err
-		}
-
-		decoder := json.NewDecoder(f)
-		if err := decoder.Decode(&block); err != nil {
-			f.Close()
-			return blockchain, err
-		}
-		f.Close()
-		blockchain = append(blockchain, block)
-	}
-
-	return blockchain, nil
-}
-
-// mineBlock performs the mining process to find a valid nonce
-func mineBlock(data string, previousBlock Block, difficulty int) Block {
-	var wg sync.WaitGroup
-	result := make(chan Block)
-	done := make(chan struct{})
-	nonceChan := make(chan uint64, 100) // Buffer untuk nonce
-	numCPU := runtime.NumCPU()
-
-	wg.Add(numCPU)
-
-	// Fungsi mining yang dijalankan oleh setiap goroutine
-	mining := func(start uint64, step uint64) {
-		defer wg.Done()
-		var nonce uint64 = start
-		prefix := strings.Repeat("0", difficulty)
-
-		for {
-			select {
-			case <-done:
-				return
-			default:
-				// Membuat blok dengan nonce saat ini
-				newBlock := Block{
-					Index:        previousBlock.Index + 1,
-					Timestamp:    time.Now().Format(time.RFC3339),
-					Data:         data,
-					Nonce:        nonce,
-					Hash:         "",
-					PreviousHash: previousBlock.Hash,
-					Difficulty:   difficulty, // **Menetapkan Difficulty**
-				}
-				newBlock.Hash = calculateHash(newBlock)
-
-				// Memeriksa apakah hash memenuhi tingkat kesulitan
-				if strings.HasPrefix(newBlock.Hash, prefix) {
-					// Mengirim hasil melalui channel
-					result <- newBlock
-					return
-				}
-
-				// Mengirim nonce terkini secara periodik
-				if nonce%100000 == 0 {
-					select {
-					case nonceChan <- nonce:
-					default:
-						// Jika channel penuh, abaikan untuk mencegah blocking
-					}
-				}
-
-				// Meningkatkan nonce sesuai langkah
-				nonce += step
-			}
-		}
-	}
-
-	// Meluncurkan goroutine mining
-	for i := 0; i < numCPU; i++ {
-		go mining(uint64(i), uint64(numCPU))
-	}
-
-	// Goroutine untuk menampilkan nonce secara dinamis
-	var monitorWg sync.WaitGroup
-	monitorWg.Add(1)
-	go func() {
-		defer monitorWg.Done()
-		lastNonce := uint64(0)
-		for nonce := range nonceChan {
-			if nonce > lastNonce {
-				// Menggunakan format string konstan dengan placeholders
-				fmt.Printf("\r%sNonce sedang diperiksa: %d%s", BoldCyan, nonce, Reset)
-				lastNonce = nonce
-			}
-		}
-	}()
-
-	// Menunggu salah satu goroutine menemukan nonce yang valid
-	foundBlock := <-result
-	close(done)
-	wg.Wait()
-
-	// Menutup channel nonceChan setelah semua goroutine selesai
-	close(nonceChan)
-	monitorWg.Wait()
-
-	fmt.Println() // Menambahkan newline setelah mining selesai
-
-	return foundBlock
-}
-
-// displayBlockchain prints all the blocks in the blockchain
-func displayBlockchain(blockchain []Block) {
-	fmt.Println(BoldYellow + "\n=== Blockchain ===" + Reset)
-	for _, block := range blockchain {
-		fmt.Println(BoldGreen + "-------------------------------------------------" + Reset)
-		fmt.Printf("%sIndex         :%s %d\n", BoldCyan, Reset, block.Index)
-		fmt.Printf("%sTimestamp     :%s %s\n", BoldCyan, Reset, block.Timestamp)
-		fmt.Printf("%sData          :%s %s\n", BoldCyan, Reset, block.Data)
-		fmt.Printf("%sNonce         :%s %d\n", BoldCyan, Reset, block.Nonce)
-		fmt.Printf("%sHash          :%s %s\n", BoldCyan, Reset, block.Hash)
-		fmt.Printf("%sPreviousHash  :%s %s\n", BoldCyan, Reset, block.PreviousHash)
-		fmt.Printf("%sDifficulty    :%s %d\n", BoldCyan, Reset, block.Difficulty) // **Menampilkan Difficulty**
-	}
-	fmt.Println(BoldGreen + "-------------------------------------------------" + Reset)
-}
-
-// isBlockchainValid checks the integrity of the blockchain
-func isBlockchainValid(blockchain []Block) bool {
-	for i, block := range blockchain {
-		// Validasi hash
-		if block.Hash != calculateHash(block) {
-			fmt.Printf(Red+"Invalid hash at block %d\n"+Reset, block.Index)
-			return false
-		}
-
-		// Validasi tingkat kesulitan berdasarkan Difficulty setiap blok
-		prefix := strings.Repeat("0", block.Difficulty)
-		if !strings.HasPrefix(block.Hash, prefix) {
-			fmt.Printf(Red+"Block %d does not meet difficulty requirements\n"+Reset, block.Index)
-			return false
-		}
-
-		// Validasi PreviousHash (kecuali untuk Genesis Block)
-		if i > 0 {
-			if block.PreviousHash != blockchain[i-1].Hash {
-				fmt.Printf(Red+"Previous hash mismatch at block %d\n"+Reset, block.Index)
-				return false
-			}
-		} else {
-			// Validasi Genesis Block's PreviousHash
-			expectedPrevHash := "0000000000000000000000000000000000000000000000000000000000000000"
-			if block.PreviousHash != expectedPrevHash {
-				fmt.Printf(Red + "Invalid PreviousHash for Genesis Block\n" + Reset)
-				return false
-			}
-		}
-	}
-
-	fmt.Println(Green + "Blockchain is valid." + Reset)
-	return true
-}
-
-// menuDisplay displays the interactive menu
-func menuDisplay() {
-	fmt.Println(BoldYellow + "\n=== Menu Blockchain ===" + Reset)
-	fmt.Println(BoldBlue + "1. Tambah Blok Baru" + Reset)
-	fmt.Println(BoldBlue + "2. Tampilkan Blockchain" + Reset)
-	fmt.Println(BoldBlue + "3. Set Tingkat Kesulitan" + Reset)
-	fmt.Println(BoldBlue + "4. Validasi Blockchain" + Reset) // **Opsi Baru**
-	fmt.Println(BoldBlue + "5. Keluar" + Reset)              // **Menyesuaikan nomor opsi**
-	fmt.Print(BoldCyan + "Pilih opsi: " + Reset)
-}
-
-func main() {
-	reader := bufio.NewReader(os.Stdin)
-	var blockchain []Block
-	var err error
-	currentDifficulty := 5 // Default difficulty
-
-	// Memuat blockchain jika ada, atau membuat genesis block
-	blockchain, err = loadBlockchain()
-	if err != nil {
-		fmt.Println(Red+"Error loading blockchain:"+Reset, err)
-		return
-	}
-
-	if len(blockchain) == 0 {
-		genesisBlock := createGenesisBlock(currentDifficulty)
-		blockchain = append(blockchain, genesisBlock)
-		// Menyimpan blok genesis
-		if err := saveBlock(genesisBlock); err != nil {
-			fmt.Println(Red+"Error menyimpan blok genesis:"+Reset, err)
-			return
-		}
-		fmt.Println(Green + "Blok genesis berhasil dibuat dan ditambahkan ke blockchain." + Reset)
-	} else {
-		// Menentukan tingkat kesulitan saat ini berdasarkan blok terakhir
-		lastBlock := blockchain[len(blockchain)-1]
-		currentDifficulty = lastBlock.Difficulty // **Mengambil Difficulty dari blok terakhir**
-		fmt.Printf(Green+"Blockchain ditemukan dengan %d blok. Tingkat kesulitan saat ini: %d\n"+Reset, len(blockchain), currentDifficulty)
-	}
-
-	for {
-		menuDisplay()
-		option, _ := reader.ReadString('\n')
-		option = strings.TrimSpace(option)
-
-		switch option {
-		case "1":
-			// Input data untuk blok baru
-			fmt.Print(BoldCyan + "Masukkan data (teks) yang akan di-mining: " + Reset)
-			data, _ := reader.ReadString('\n')
-			data = strings.TrimSpace(data)
-
-			// Gunakan tingkat kesulitan saat ini
-			fmt.Printf(BoldYellow+"Menggunakan tingkat kesulitan saat ini: %d\n"+Reset, currentDifficulty)
-
-			fmt.Println(BoldYellow + "\nMemulai proses mining..." + Reset)
-			previousBlock := blockchain[len(blockchain)-1]
-			startTime := time.Now()
-			newBlock := mineBlock(data, previousBlock, currentDifficulty)
-			elapsed := time.Since(startTime)
-
-			// Menambahkan blok baru ke blockchain
-			blockchain = append(blockchain, newBlock)
-
-			// Menyimpan blok baru sebagai file JSON
-			if err := saveBlock(newBlock); err != nil {
-				fmt.Println(Red+"Error menyimpan blok:"+Reset, err)
-				continue
-			}
-
-			fmt.Println(Green + "Blok baru berhasil ditambahkan:" + Reset)
-			fmt.Printf("%sIndex         :%s %d\n", BoldCyan, Reset, newBlock.Index)
-			fmt.Printf("%sNonce         :%s %d\n", BoldCyan, Reset, newBlock.Nonce)
-			fmt.Printf("%sHash          :%s %s\n", BoldCyan, Reset, newBlock.Hash)
-			fmt.Printf("%sPreviousHash  :%s %s\n", BoldCyan, Reset, newBlock.PreviousHash)
-			fmt.Printf("%sDifficulty    :%s %d\n", BoldCyan, Reset, newBlock.Difficulty)
-			fmt.Printf("%sWaktu         :%s %s\n", BoldCyan, Reset, elapsed)
-
-		case "2":
-			// Tampilkan seluruh blockchain
-			if len(blockchain) == 0 {
-				fmt.Println(Yellow + "Blockchain masih kosong." + Reset)
-			} else {
-				displayBlockchain(blockchain)
-			}
-
-		case "3":
-			// Set tingkat kesulitan
-			fmt.Print(BoldCyan + "Masukkan tingkat kesulitan baru (jumlah nol di awal hash): " + Reset)
-			difficultyInput, _ := reader.ReadString('\n')
-			difficultyInput = strings.TrimSpace(difficultyInput)
-			newDifficulty, err := strconv.Atoi(difficultyInput)
-			if err != nil || newDifficulty < 0 {
-				fmt.Println(Red + "Tingkat kesulitan harus berupa angka non-negatif." + Reset)
-				continue
-			}
-			currentDifficulty = newDifficulty
-			fmt.Printf(Green+"Tingkat kesulitan berhasil diubah menjadi %d.\n"+Reset, currentDifficulty)
-
-		case "4":
-			// Validasi Blockchain
-			fmt.Println(BoldYellow + "Memvalidasi blockchain..." + Reset)
-			isBlockchainValid(blockchain)
-
-		case "5":
-			// Keluar dari program
-			fmt.Println(Yellow + "Keluar dari program." + Reset)
-			return
-
-		default:
-			fmt.Println(Red + "Opsi tidak valid. Silakan pilih opsi yang tersedia." + Reset)
-		}
-	}
-}
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ANSI escape codes for coloring
+const (
+	Reset      = "\033[0m"
+	Bold       = "\033[1m"
+	Red        = "\033[31m"
+	Green      = "\033[32m"
+	Yellow     = "\033[33m"
+	Blue       = "\033[34m"
+	Magenta    = "\033[35m"
+	Cyan       = "\033[36m"
+	BoldYellow = "\033[1;33m"
+	BoldCyan   = "\033[1;36m"
+	BoldGreen  = "\033[1;32m"
+	BoldRed    = "\033[1;31m"
+	BoldBlue   = "\033[1;34m" // Menambahkan definisi BoldBlue
+)
+
+// Block represents each block in the blockchain
+type Block struct {
+	Index        int    `json:"index"`
+	Timestamp    string `json:"timestamp"`
+	Data         string `json:"data"`
+	Nonce        uint64 `json:"nonce"`
+	Hash         string `json:"hash"`
+	PreviousHash string `json:"previous_hash"`
+	Difficulty   int    `json:"difficulty"` // **Field Difficulty ditambahkan**
+	// Pesan bebas dari miner ("graffiti"); termasuk dalam hash jika tidak kosong
+	ExtraData string `json:"extra_data,omitempty"`
+
+	// Tanda tangan finalitas validator; tidak termasuk dalam hash blok
+	Signatures []ValidatorSignature `json:"signatures,omitempty"`
+	// Bukti double signing yang memicu slashing; digest-nya termasuk dalam hash jika tidak kosong
+	Evidence []DoubleSignEvidence `json:"evidence,omitempty"`
+}
+
+// calculateHash calculates the proof-of-work hash (SHA-256 by default) of a block's contents
+func calculateHash(cfg GenesisConfig, block Block) string {
+	hash := powHash(cfg, blockRecord(block))
+	return hex.EncodeToString(hash[:])
+}
+
+// createGenesisBlock creates the first block in the blockchain by mining it with default difficulty
+func createGenesisBlock(cfg GenesisConfig, difficulty int) Block {
+	fmt.Println(BoldYellow + "Membuat blok genesis melalui proses mining..." + Reset)
+
+	// Blok Dummy dengan Index=-1 dan PreviousHash=64 nol
+	dummyBlock := Block{
+		Index:        -1,
+		Timestamp:    "",
+		Data:         "",
+		Nonce:        0,
+		Hash:         genesisPreviousHash,
+		PreviousHash: "",
+	}
+
+	// Mine Genesis Block dengan menggunakan dummyBlock sebagai previousBlock
+	genesisBlock, _ := mineBlock(context.Background(), cfg, "Genesis Block", dummyBlock, difficulty, &consoleMiningObserver{})
+	return genesisBlock
+}
+
+// saveBlock saves a block to the block store of the chain
+func saveBlock(store BlockStore, block Block) error {
+	defer startSpan("store.save")()
+	return store.SaveBlock(block)
+}
+
+// loadBlockchain loads every block from the block store of the chain
+func loadBlockchain(store BlockStore) ([]Block, error) {
+	defer startSpan("store.load")()
+	return store.LoadBlocks()
+}
+
+// loadChain loads the genesis config and every block of store, as most subcommands need both
+func loadChain(store BlockStore) (GenesisConfig, []Block, error) {
+	cfg, err := loadGenesisConfig(store)
+	if err != nil {
+		return GenesisConfig{}, nil, err
+	}
+	blockchain, err := loadBlockchain(store)
+	return cfg, blockchain, err
+}
+
+// decodeBlock parses a single JSON-encoded block from untrusted bytes.
+// It must never panic on malformed input, which makes it a suitable target
+// for Go native fuzzing (func FuzzDecodeBlock(f *testing.F) calling it directly).
+func decodeBlock(data []byte) (Block, error) {
+	var block Block
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(&block); err != nil {
+		return Block{}, err
+	}
+
+	// Tolak data sisa setelah objek JSON pertama
+	if _, err := decoder.Token(); err != io.EOF {
+		return Block{}, fmt.Errorf("unexpected data after block")
+	}
+
+	// Difficulty di luar rentang akan membuat strings.Repeat panic saat validasi
+	if block.Difficulty < 0 || block.Difficulty > 64 {
+		return Block{}, fmt.Errorf("invalid difficulty %d", block.Difficulty)
+	}
+
+	return block, nil
+}
+
+// mineBlock performs the mining process to find a valid nonce.
+// Progress is reported to observer; mining stops with ctx.Err() when ctx is cancelled.
+func mineBlock(ctx context.Context, cfg GenesisConfig, data string, previousBlock Block, difficulty int, observer MiningObserver) (Block, error) {
+	return mineBlockResumable(ctx, cfg, data, "", nil, previousBlock, difficulty, observer, nil, nil)
+}
+
+// mineBlockResumable is mineBlock with the miner's extra data, the slashing evidence to include
+// and resumable search state: workers continue from the nonces in resume when it is not nil, and
+// checkpoint, when not nil, periodically receives the nonce each worker has reached, and a final
+// time when mining is cancelled.
+func mineBlockResumable(ctx context.Context, cfg GenesisConfig, data, extraData string, evidence []DoubleSignEvidence, previousBlock Block, difficulty int, observer MiningObserver, resume *miningState, checkpoint func(*miningState)) (Block, error) {
+	var wg sync.WaitGroup
+	result := make(chan Block)
+	done := make(chan struct{})
+	nonceChan := make(chan uint64, 100) // Buffer untuk nonce
+	numCPU := runtime.NumCPU()
+	startTime := time.Now()
+
+	// Nonce berikutnya per worker; worker i mencoba i, i+numCPU, i+2*numCPU, ...
+	if resume != nil {
+		numCPU = len(resume.Next)
+	}
+	next := make([]atomic.Uint64, numCPU)
+	for i := range next {
+		next[i].Store(uint64(i))
+		if resume != nil {
+			next[i].Store(resume.Next[i])
+		}
+	}
+	snapshot := func() *miningState {
+		state := &miningState{Index: previousBlock.Index + 1, PreviousHash: previousBlock.Hash, Data: data, Difficulty: difficulty, Next: make([]uint64, numCPU)}
+		for i := range next {
+			state.Next[i] = next[i].Load()
+		}
+		return state
+	}
+
+	wg.Add(numCPU)
+
+	// Fungsi mining yang dijalankan oleh setiap goroutine
+	mining := func(worker int, step uint64) {
+		defer wg.Done()
+		var nonce uint64 = next[worker].Load()
+		hasher := newMidstateHasher(cfg)
+		var second int64
+		var timestamp, header string
+		suffix := previousBlock.Hash + extraData
+		if len(evidence) > 0 {
+			suffix += evidenceDigest(evidence)
+		}
+
+		for {
+			// Posisi worker dicatat berkala agar pencarian dapat dilanjutkan
+			if nonce/step%4096 == 0 {
+				next[worker].Store(nonce)
+			}
+			select {
+			case <-done:
+				next[worker].Store(nonce)
+				return
+			default:
+				// Timestamp hanya berubah tiap detik; prefix header (index, timestamp, data)
+				// sesuai urutan blockRecord dan midstate SHA-256-nya hanya dihitung ulang saat itu
+				if now := time.Now(); now.Unix() != second {
+					second = now.Unix()
+					timestamp = now.Format(time.RFC3339)
+					header = strconv.Itoa(previousBlock.Index+1) + timestamp + data
+				}
+
+				// Memeriksa apakah hash memenuhi tingkat kesulitan
+				if sum := hasher.hash(header, nonce, suffix); meetsDifficulty(sum, difficulty) {
+					newBlock := Block{
+						Index:        previousBlock.Index + 1,
+						Timestamp:    timestamp,
+						Data:         data,
+						Nonce:        nonce,
+						Hash:         hex.EncodeToString(sum[:]),
+						PreviousHash: previousBlock.Hash,
+						Difficulty:   difficulty, // **Menetapkan Difficulty**
+						ExtraData:    extraData,
+						Evidence:     evidence,
+					}
+					// Mengirim hasil melalui channel, kecuali goroutine lain sudah menang
+					select {
+					case result <- newBlock:
+					case <-done:
+					}
+					return
+				}
+
+				// Mengirim nonce terkini secara periodik
+				if nonce%100000 == 0 {
+					select {
+					case nonceChan <- nonce:
+					default:
+						// Jika channel penuh, abaikan untuk mencegah blocking
+					}
+				}
+
+				// Meningkatkan nonce sesuai langkah
+				nonce += step
+			}
+		}
+	}
+
+	// Meluncurkan goroutine mining
+	for i := 0; i < numCPU; i++ {
+		go mining(i, uint64(numCPU))
+	}
+
+	// Goroutine yang meneruskan progres nonce ke observer
+	var monitorWg sync.WaitGroup
+	monitorWg.Add(1)
+	go func() {
+		defer monitorWg.Done()
+		for nonce := range nonceChan {
+			observer.MiningProgress(nonce)
+		}
+	}()
+
+	// Menunggu salah satu goroutine menemukan nonce yang valid atau pembatalan
+	var foundBlock Block
+	var err error
+	ticker := time.NewTicker(miningCheckpointInterval)
+	defer ticker.Stop()
+wait:
+	for {
+		select {
+		case foundBlock = <-result:
+			break wait
+		case <-ctx.Done():
+			err = ctx.Err()
+			break wait
+		case <-ticker.C:
+			if checkpoint != nil {
+				checkpoint(snapshot())
+			}
+		}
+	}
+	close(done)
+	wg.Wait()
+	if err != nil && checkpoint != nil {
+		checkpoint(snapshot())
+	}
+
+	// Menutup channel nonceChan setelah semua goroutine selesai
+	close(nonceChan)
+	monitorWg.Wait()
+
+	if err != nil {
+		observer.MiningCancelled(time.Since(startTime))
+		return Block{}, err
+	}
+	observer.MiningSolved(foundBlock, time.Since(startTime))
+	return foundBlock, nil
+}
+
+// displayBlockchain prints all the blocks in the blockchain, whose genesis config is cfg
+func displayBlockchain(cfg GenesisConfig, blockchain []Block) {
+	fmt.Println(BoldYellow + "\n=== Blockchain ===" + Reset)
+	for _, block := range blockchain {
+		fmt.Println(BoldGreen + "-------------------------------------------------" + Reset)
+		fmt.Printf("%sIndex         :%s %d\n", BoldCyan, Reset, block.Index)
+		fmt.Printf("%sTimestamp     :%s %s\n", BoldCyan, Reset, block.Timestamp)
+		fmt.Printf("%sData          :%s %s\n", BoldCyan, Reset, block.Data)
+		fmt.Printf("%sNonce         :%s %d\n", BoldCyan, Reset, block.Nonce)
+		fmt.Printf("%sHash          :%s %s\n", BoldCyan, Reset, block.Hash)
+		fmt.Printf("%sPreviousHash  :%s %s\n", BoldCyan, Reset, block.PreviousHash)
+		fmt.Printf("%sDifficulty    :%s %d\n", BoldCyan, Reset, block.Difficulty) // **Menampilkan Difficulty**
+		if block.ExtraData != "" {
+			fmt.Printf("%sExtra data    :%s %q\n", BoldCyan, Reset, block.ExtraData)
+		}
+		printBlockSize(cfg, block)
+		printDifficultyTarget(block)
+		if len(block.Signatures) > 0 {
+			fmt.Printf("%sFinalisasi    :%s %d tanda tangan validator\n", BoldCyan, Reset, len(block.Signatures))
+		}
+		for _, evidence := range block.Evidence {
+			fmt.Printf("%sSlashing      :%s validator %s (double signing di blok %d)\n", BoldCyan, Reset, evidence.Validator, evidence.Index)
+		}
+	}
+	fmt.Println(BoldGreen + "-------------------------------------------------" + Reset)
+}
+
+// isBlockchainValid checks the integrity of the blockchain against every validation rule
+func isBlockchainValid(cfg GenesisConfig, blockchain []Block) bool {
+	for i := range blockchain {
+		if rule, err := validateBlock(cfg, blockchain, i); err != nil {
+			fmt.Printf(Red+"[%s] %v\n"+Reset, rule, err)
+			return false
+		}
+	}
+
+	fmt.Println(Green + "Blockchain is valid." + Reset)
+	return true
+}
+
+// menuDisplay displays the interactive menu
+func menuDisplay() {
+	fmt.Println(BoldYellow + "\n=== Menu Blockchain ===" + Reset)
+	fmt.Println(BoldBlue + "1. Tambah Blok Baru" + Reset)
+	fmt.Println(BoldBlue + "2. Tampilkan Blockchain" + Reset)
+	fmt.Println(BoldBlue + "3. Set Tingkat Kesulitan" + Reset)
+	fmt.Println(BoldBlue + "4. Validasi Blockchain" + Reset) // **Opsi Baru**
+	fmt.Println(BoldBlue + "5. Keluar" + Reset)              // **Menyesuaikan nomor opsi**
+	fmt.Print(BoldCyan + "Pilih opsi: " + Reset)
+}
+
+func main() {
+	chaosEnabled := flag.Bool("chaos", false, "Aktifkan chaos mode yang menyuntikkan fault penyimpanan secara acak")
+	chaosSeed := flag.Int64("chaos-seed", 1, "Seed untuk chaos mode agar hasilnya dapat direproduksi")
+	chaosRate := flag.Float64("chaos-rate", 0.2, "Probabilitas fault per operasi penyimpanan pada chaos mode")
+	strategyName := flag.String("strategy", "honest", "Strategi miner: "+strings.Join(minerStrategyNames(), ", "))
+	apiAddr := flag.String("api-addr", "", "Alamat REST API, misalnya :8080 (kosong berarti nonaktif)")
+	apiKeysPath := flag.String("api-keys", "", "File JSON berisi API key; jika kosong API tidak memakai autentikasi")
+	apiTLSCert := flag.String("api-tls-cert", "", "File sertifikat TLS untuk REST API (lihat perintah gencert)")
+	apiTLSKey := flag.String("api-tls-key", "", "File private key TLS untuk REST API")
+	storageKeyFile := flag.String("storage-key-file", "", "File berisi passphrase untuk mengenkripsi blok di disk (atau gunakan BLOCKCHAIN_STORAGE_KEY)")
+	retargetName := flag.String("retarget", retargetManual, "Algoritma retarget untuk chain baru: "+retargetManual+", "+strings.Join(retargeterNames(), ", "))
+	genesisDifficulty := flag.Int("genesis-difficulty", 5, "Tingkat kesulitan awal untuk chain baru (default: disarankan dari hashrate mesin ini)")
+	consensusName := flag.String("consensus", consensusPoW, "Mesin konsensus untuk chain baru: "+consensusPoW+" atau "+consensusHybrid)
+	validatorDir := flag.String("validator-dir", "", "Direktori key validator (<nama>.key) untuk menandatangani blok pada konsensus hybrid")
+	powName := flag.String("pow", powSHA256, "Algoritma proof-of-work untuk chain baru: "+strings.Join(powAlgorithmNames(), ", "))
+	powMemory := flag.Int("pow-memory", defaultPoWMemoryKiB, "Ukuran scratchpad PoW memhard dalam KiB untuk chain baru")
+	epochLength := flag.Int("epoch-length", defaultEpochLength, "Jumlah blok per epoch untuk chain hybrid baru (0 berarti perubahan validator set berlaku langsung)")
+	blockReward := flag.Float64("block-reward", defaultGenesisConfig.BlockReward, "Reward per blok untuk validator chain hybrid baru")
+	medianTimeSpan := flag.Int("median-time-span", defaultMedianTimeSpan, "Timestamp blok chain baru harus melewati median sekian blok terakhir (0 berarti cukup tidak mundur dari blok sebelumnya)")
+	maxDataSize := flag.Int("max-data-size", 0, "Ukuran maksimum Data blok dalam byte untuk chain baru (0 berarti tanpa batas)")
+	targetInterval := flag.Float64("target-interval", defaultGenesisConfig.TargetInterval, "Target interval blok dalam detik untuk chain baru")
+	rpcURL := flag.String("rpc-url", "", "Jalankan sebagai thin client terhadap REST API node lain, misalnya http://server:8080 (auto berarti cari di jaringan lokal)")
+	rpcNodeID := flag.String("rpc-node-id", "", "Node ID yang diharapkan dari node remote; koneksi ditolak jika node tidak dapat membuktikannya")
+	rpcKey := flag.String("rpc-key", "", "Secret API key untuk node remote pada thin client mode")
+	apiRate := flag.Float64("api-rate", 0, "Batas request REST API per detik per IP (0 berarti tanpa batas)")
+	apiBurst := flag.Int("api-burst", 10, "Jumlah request beruntun yang diizinkan sebelum rate limit berlaku")
+	schedulesPath := flag.String("schedules", "", "File JSON berisi jadwal [{\"data\": ..., \"every_blocks\": N} atau {\"data\": ..., \"interval\": \"30s\"}] yang mengirim permintaan blok berulang")
+	webhooksPath := flag.String("webhooks", "", "File JSON berisi webhook [{\"url\": ..., \"events\": [...]}] yang menerima event node")
+	pprofFlag := flag.Bool("pprof", false, "Aktifkan endpoint net/http/pprof (permission admin) di REST API")
+	traceSummary := flag.Bool("trace-summary", false, "Cetak ringkasan waktu mining, validasi, penyimpanan, dan sinkronisasi saat program berhenti")
+	otlpEndpoint := flag.String("otlp-endpoint", "", "Kirim jejak OpenTelemetry setiap blok ke collector OTLP/HTTP ini, misalnya http://localhost:4318")
+	notifyAfter := flag.Duration("notify-after", 0, "Beri tahu saat mining yang lebih lama dari durasi ini selesai atau gagal (0 berarti nonaktif)")
+	notifyMode := flag.String("notify", notifyBoth, "Cara memberi tahu: bell (bunyi terminal), desktop, atau both")
+	healFrom := flag.String("heal-from", "", "Ganti blok rusak dengan salinan dari peer (URL dipisah koma, atau auto) alih-alih berhenti")
+	verifyName := flag.String("verify", "", "Verifikasi blockchain saat start pada level quick, standard, atau paranoid (kosong berarti tanpa verifikasi)")
+	dataDir := flag.String("datadir", "blocks", "Direktori blok dan genesis.json chain")
+	ephemeral := flag.Bool("ephemeral", false, "Simpan blockchain di memori saja: selalu mulai dari genesis baru dan tidak menulis apa pun ke disk")
+	snapshotEvery := flag.Int("snapshot-every", 0, "Snapshot chain otomatis setiap sekian blok (0 berarti nonaktif, lihat perintah snapshot)")
+	snapshotKeep := flag.Int("snapshot-keep", defaultSnapshotKeep, "Jumlah snapshot otomatis terbaru yang disimpan")
+	backup := flag.String("backup", "", "Backup inkremental berkala ke direktori atau s3://bucket/prefix (lihat perintah backup dan restore)")
+	backupInterval := flag.Duration("backup-interval", 10*time.Minute, "Jarak antar backup berkala")
+	extraData := flag.String("extra-data", "", "Pesan miner (maksimal 32 byte) yang disertakan di setiap blok yang di-mining node ini dan ikut di-hash")
+	hasherCommand := flag.String("hasher", "", "Perintah proses hasher eksternal (protokol JSON per baris lewat stdin/stdout, lihat perintah hasher)")
+	readOnly := flag.Bool("read-only", false, "Buka -datadir read-only tanpa menulis apa pun; tanpa subcommand menjalankan explorer publik: hanya endpoint baca REST API, tanpa menu, mining, dan aksi admin (membutuhkan -api-addr)")
+	headless := flag.Bool("headless", false, "Jalankan node tanpa menu; mining hanya lewat REST API (membutuhkan -api-addr)")
+	discovery := flag.Bool("discovery", true, "Umumkan REST API ke jaringan lokal lewat UDP broadcast agar node lain dapat menemukannya")
+	flag.Parse()
+
+	strategy, err := newMinerStrategy(*strategyName)
+	if err != nil {
+		fmt.Println(Red+"Error:"+Reset, err)
+		return
+	}
+
+	if *ephemeral && *readOnly {
+		fmt.Println(Red + "-ephemeral dan -read-only tidak dapat digabung." + Reset)
+		return
+	}
+
+	// Mengaktifkan enkripsi blok di disk jika key tersedia
+	files := fileBlockStore{dir: *dataDir}
+	passphrase, err := loadStorageKey(*storageKeyFile)
+	if err != nil {
+		fmt.Println(Red+"Error membaca storage key:"+Reset, err)
+		return
+	}
+	if passphrase != "" {
+		files.cipher, err = newStorageCipher(files, passphrase, !*readOnly && !*ephemeral)
+		if err != nil {
+			fmt.Println(Red+"Error:"+Reset, err)
+			return
+		}
+		fmt.Println(Green + "Enkripsi blok di disk aktif (AES-GCM, key PBKDF2)." + Reset)
+	}
+
+	// Chaos mode hanya berlaku untuk node, bukan untuk subcommand
+	if *chaosEnabled && flag.NArg() == 0 {
+		files.chaos = newChaosInjector(*chaosSeed, *chaosRate)
+		fmt.Printf(BoldRed+"Chaos mode aktif (seed %d, rate %.2f).\n"+Reset, *chaosSeed, *chaosRate)
+		defer files.chaos.printSummary()
+	}
+
+	var store BlockStore = files
+	switch {
+	case *ephemeral:
+		store = newMemoryBlockStore()
+		fmt.Println(Yellow + "Mode ephemeral: blockchain hanya disimpan di memori dan hilang saat program berhenti." + Reset)
+	case *readOnly:
+		store = readOnlyBlockStore{store}
+		fmt.Printf(Yellow+"%s dibuka read-only.\n"+Reset, *dataDir)
+	}
+
+	// Menjalankan subcommand non-interaktif jika diberikan
+	if flag.NArg() > 0 {
+		if err := runCommand(store, flag.Args()); err != nil {
+			fmt.Println(Red+"Error:"+Reset, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+
+	// Thin client mode: semua aksi menu dijalankan di node remote
+	if *rpcURL == "auto" {
+		fmt.Println(BoldYellow + "Mencari node di jaringan lokal..." + Reset)
+		nodes, err := discoverNodes(discoveryInterval + time.Second)
+		if err != nil {
+			fmt.Println(Red+"Error:"+Reset, err)
+			return
+		}
+		if len(nodes) == 0 {
+			fmt.Println(Red + "Tidak ada node yang ditemukan di jaringan lokal." + Reset)
+			return
+		}
+		// Dengan -rpc-node-id, pilih node dengan identitas tersebut alih-alih node pertama
+		*rpcURL = nodes[0].URL
+		for _, node := range nodes {
+			if node.ID == *rpcNodeID {
+				*rpcURL = node.URL
+			}
+		}
+	}
+	if *rpcURL != "" {
+		client := newRemoteClient(*rpcURL, *rpcKey)
+		if *rpcNodeID != "" {
+			if err := client.VerifyIdentity(*rpcNodeID); err != nil {
+				fmt.Println(Red+"Identitas node remote tidak valid:"+Reset, err)
+				return
+			}
+			fmt.Printf(Green+"Identitas node %s terverifikasi.\n"+Reset, *rpcNodeID)
+		}
+		runThinClient(client, reader)
+		return
+	}
+
+	currentDifficulty := *genesisDifficulty // Default difficulty
+
+	// Memuat blockchain jika ada, atau membuat genesis block
+	blockchain, err := loadBlockchain(store)
+	var corrupt *corruptBlockError
+	if errors.As(err, &corrupt) && *healFrom != "" {
+		fmt.Println(Yellow+"Blok rusak, mencoba memperbaiki dari peer:"+Reset, err)
+		blockchain, err = healOnStart(store, *healFrom)
+	}
+	if err != nil {
+		fmt.Println(Red+"Error loading blockchain:"+Reset, err)
+		return
+	}
+	cfg, err := loadGenesisConfig(store)
+	if err != nil {
+		fmt.Println(Red+"Error loading genesis config:"+Reset, err)
+		return
+	}
+
+	// Key validator lokal dipakai untuk memfinalisasi blok pada konsensus hybrid
+	var validatorKeys map[string]ed25519.PrivateKey
+	var localValidators []Validator
+	if *validatorDir != "" {
+		validatorKeys, localValidators, err = loadValidatorKeys(*validatorDir)
+		if err != nil {
+			fmt.Println(Red+"Error memuat key validator:"+Reset, err)
+			return
+		}
+	}
+
+	if *headless && *apiAddr == "" {
+		fmt.Println(Red + "Mode -headless membutuhkan -api-addr." + Reset)
+		return
+	}
+	if *readOnly && (len(blockchain) == 0 || *apiAddr == "") {
+		fmt.Println(Red + "Mode -read-only membutuhkan -api-addr dan blockchain yang sudah ada." + Reset)
+		return
+	}
+
+	if len(blockchain) == 0 {
+		// Parameter chain ditetapkan saat blok genesis dibuat
+		cfg = defaultGenesisConfig
+		cfg.Retarget = *retargetName
+		cfg.TargetInterval = *targetInterval
+		cfg.PoW = *powName
+		cfg.PoWMemoryKiB = *powMemory
+		cfg.Consensus = *consensusName
+		cfg.BlockReward = *blockReward
+		cfg.MaxDataSize = *maxDataSize
+		cfg.MedianTimeSpan = *medianTimeSpan
+		if *consensusName == consensusHybrid {
+			// Validator set chain baru diambil dari key di -validator-dir
+			cfg.Validators = localValidators
+			cfg.EpochLength = *epochLength
+		}
+		if *genesisDifficulty < 0 || *genesisDifficulty > 64 {
+			fmt.Println(Red + "Tingkat kesulitan awal harus berupa angka antara 0 dan 64." + Reset)
+			return
+		}
+		if err := cfg.validate(); err != nil {
+			fmt.Println(Red+"Error:"+Reset, err)
+			return
+		}
+		if err := saveGenesisConfig(store, cfg); err != nil {
+			fmt.Println(Red+"Error menyimpan genesis config:"+Reset, err)
+			return
+		}
+
+		// Tanpa -genesis-difficulty, tingkat kesulitan disesuaikan dengan kecepatan mesin ini
+		difficultySet := false
+		flag.Visit(func(f *flag.Flag) { difficultySet = difficultySet || f.Name == "genesis-difficulty" })
+		if !difficultySet {
+			currentDifficulty = firstRunDifficulty(cfg, reader, currentDifficulty, !*headless)
+		}
+
+		genesisBlock := createGenesisBlock(cfg, currentDifficulty)
+		finalizeBlock(cfg, validatorKeys, &genesisBlock)
+		blockchain = append(blockchain, genesisBlock)
+		// Menyimpan blok genesis
+		if err := saveBlock(store, genesisBlock); err != nil {
+			fmt.Println(Red+"Error menyimpan blok genesis:"+Reset, err)
+			return
+		}
+		fmt.Println(Green + "Blok genesis berhasil dibuat dan ditambahkan ke blockchain." + Reset)
+	} else {
+		// Menentukan tingkat kesulitan saat ini berdasarkan blok terakhir
+		lastBlock := blockchain[len(blockchain)-1]
+		currentDifficulty = lastBlock.Difficulty // **Mengambil Difficulty dari blok terakhir**
+		if difficulty, ok := nextDifficulty(cfg, blockchain); ok {
+			currentDifficulty = difficulty
+		}
+		fmt.Printf(Green+"Blockchain ditemukan dengan %d blok. Tingkat kesulitan saat ini: %d\n"+Reset, len(blockchain), currentDifficulty)
+
+		// Verifikasi saat start menolak menjalankan node di atas chain yang rusak; dengan
+		// -heal-from blok yang rusak diganti dari peer alih-alih berhenti
+		if *verifyName == "" && *healFrom != "" {
+			*verifyName = "standard"
+		}
+		if *verifyName != "" {
+			level, err := parseVerifyLevel(*verifyName)
+			if err != nil {
+				fmt.Println(Red+"Error:"+Reset, err)
+				return
+			}
+			elapsed, rule, err := verifyChain(cfg, blockchain, level)
+			if err != nil && *healFrom != "" {
+				fmt.Printf(Yellow+"Verifikasi %s gagal: [%s] %v; mencoba memperbaiki dari peer.\n"+Reset, level, rule, err)
+				if blockchain, err = healOnStart(store, *healFrom); err == nil {
+					elapsed, rule, err = verifyChain(cfg, blockchain, level)
+				}
+			}
+			if err != nil {
+				fmt.Printf(Red+"Verifikasi %s gagal dalam %v: [%s] %v\n"+Reset, level, elapsed, rule, err)
+				return
+			}
+			fmt.Printf(Green+"Verifikasi %s selesai dalam %v.\n"+Reset, level, elapsed)
+		}
+	}
+	chain := newChain(cfg, store, blockchain, currentDifficulty)
+
+	// Webhook menerima event blok, pekerjaan mining, dan kegagalan validasi
+	config := NodeConfig{
+		Strategy:  strategy,
+		Hasher:    *hasherCommand,
+		ExtraData: *extraData,
+		APIAddr:   *apiAddr,
+		TLSCert:   *apiTLSCert,
+		TLSKey:    *apiTLSKey,
+		APIRate:   *apiRate,
+		APIBurst:  *apiBurst,
+		ReadOnly:  *readOnly,
+		Discovery: *discovery,
+		Pprof:     *pprofFlag,
+		OTLP:      *otlpEndpoint,
+
+		ValidatorKeys: validatorKeys,
+
+		NotifyAfter: *notifyAfter,
+		Notify:      *notifyMode,
+
+		SnapshotEvery: *snapshotEvery,
+		SnapshotKeep:  *snapshotKeep,
+
+		Backup:         *backup,
+		BackupInterval: *backupInterval,
+	}
+	if *webhooksPath != "" {
+		if config.Webhooks, err = loadWebhooks(*webhooksPath); err != nil {
+			fmt.Println(Red+"Error memuat webhook:"+Reset, err)
+			return
+		}
+	}
+	if *schedulesPath != "" {
+		if config.Schedules, err = loadSchedules(cfg, *schedulesPath); err != nil {
+			fmt.Println(Red+"Error memuat jadwal:"+Reset, err)
+			return
+		}
+	}
+	if *apiKeysPath != "" {
+		if config.APIKeys, err = loadAPIKeys(*apiKeysPath); err != nil {
+			fmt.Println(Red+"Error memuat API key:"+Reset, err)
+			return
+		}
+	}
+
+	// Node menjalankan antrian mining (melayani menu dan REST API secara berurutan), webhook,
+	// REST API, dan discovery di background
+	node, err := NewNode(chain, config)
+	if err != nil {
+		fmt.Println(Red+"Error:"+Reset, err)
+		return
+	}
+	if err := node.Start(context.Background()); err != nil {
+		fmt.Println(Red+"Error:"+Reset, err)
+		return
+	}
+	defer node.Stop()
+	if *traceSummary {
+		defer printSpanSummary()
+	}
+	queue := node.Queue
+	if *hasherCommand != "" {
+		fmt.Printf(Green+"Mining dilakukan oleh hasher eksternal: %s\n"+Reset, *hasherCommand)
+	}
+	if *apiAddr != "" {
+		fmt.Printf(Green+"REST API berjalan di %s (autentikasi: %t, TLS: %t, node ID: %s).\n"+Reset, *apiAddr, config.APIKeys != nil, *apiTLSCert != "", node.Identity.ID)
+	}
+
+	// Mode explorer publik tidak menampilkan menu; node berjalan sampai dihentikan dengan Ctrl+C
+	if *readOnly {
+		fmt.Println(BoldYellow + "Mode read-only: hanya endpoint baca yang aktif. Tekan Ctrl+C untuk berhenti." + Reset)
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		<-ctx.Done()
+		stop()
+		return
+	}
+	if *headless {
+		fmt.Println(BoldYellow + "Mode headless: mining lewat REST API. Tekan Ctrl+C untuk berhenti." + Reset)
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		<-ctx.Done()
+		stop()
+		return
+	}
+
+	for {
+		menuDisplay()
+		option, _ := reader.ReadString('\n')
+		option = strings.TrimSpace(option)
+
+		switch option {
+		case "1":
+			// Tawarkan melanjutkan mining yang terputus untuk blok berikutnya
+			var resume *miningState
+			if state, err := loadMiningState(store); err == nil && state != nil && state.matches(chain.Tip(), chain.Difficulty()) {
+				fmt.Printf(BoldCyan+"Mining blok %d dengan data %q terputus setelah %d percobaan. Lanjutkan? (y/n): "+Reset, state.Index, state.Data, state.attempts())
+				answer, _ := reader.ReadString('\n')
+				if strings.EqualFold(strings.TrimSpace(answer), "y") {
+					resume = state
+				} else {
+					clearMiningState(store)
+				}
+			}
+
+			var data string
+			if resume == nil {
+				// Input data untuk blok baru
+				fmt.Print(BoldCyan + "Masukkan data (teks) yang akan di-mining: " + Reset)
+				data, _ = reader.ReadString('\n')
+				data = strings.TrimSpace(data)
+			}
+
+			// Gunakan tingkat kesulitan saat ini
+			currentDifficulty := chain.Difficulty()
+			fmt.Printf(BoldYellow+"Menggunakan tingkat kesulitan saat ini: %d\n"+Reset, currentDifficulty)
+
+			// Permintaan blok masuk ke antrian mining yang sama dengan REST API
+			var job miningJob
+			var err error
+			if resume != nil {
+				job, err = queue.Resume(resume, &consoleMiningObserver{})
+			} else {
+				job, err = queue.Submit(data, &consoleMiningObserver{}, "")
+			}
+			if err != nil {
+				fmt.Println(Red+"Error:"+Reset, err)
+				continue
+			}
+			if job.Warning != "" {
+				fmt.Println(Yellow + "Peringatan: " + job.Warning + Reset)
+			}
+			ahead := 0
+			for _, other := range queue.Jobs() {
+				if other.ID < job.ID && (other.Status == jobQueued || other.Status == jobMining) {
+					ahead++
+				}
+			}
+			if ahead > 0 {
+				fmt.Printf(Yellow+"Pekerjaan mining #%d menunggu %d pekerjaan lain di antrian...\n"+Reset, job.ID, ahead)
+			}
+
+			fmt.Println(BoldYellow + "\nMemulai proses mining..." + Reset)
+			// Ctrl+C membatalkan mining tanpa keluar dari program
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			startTime := time.Now()
+			jobID := job.ID
+			job, err = queue.Wait(ctx, jobID)
+			stop()
+			if err != nil {
+				queue.Cancel(jobID)
+				job, _ = queue.Wait(context.Background(), jobID)
+			}
+			elapsed := time.Since(startTime)
+
+			switch {
+			case job.Status == jobCancelled:
+				continue
+			case job.Status == jobFailed && job.Block == nil:
+				fmt.Println(Red+"Mining gagal:"+Reset, job.Error)
+				continue
+			case job.Status == jobFailed && len(job.Published) == 0:
+				fmt.Println(Red+"Error menambahkan blok:"+Reset, job.Error)
+				continue
+			case len(job.Published) == 0:
+				fmt.Printf(Yellow+"Blok %d ditahan oleh strategi %s.\n"+Reset, job.Block.Index, strategy.Name())
+				continue
+			}
+
+			for _, block := range job.Published {
+				fmt.Println(Green + "Blok baru berhasil ditambahkan:" + Reset)
+				fmt.Printf("%sIndex         :%s %d\n", BoldCyan, Reset, block.Index)
+				fmt.Printf("%sNonce         :%s %d\n", BoldCyan, Reset, block.Nonce)
+				fmt.Printf("%sHash          :%s %s\n", BoldCyan, Reset, block.Hash)
+				fmt.Printf("%sPreviousHash  :%s %s\n", BoldCyan, Reset, block.PreviousHash)
+				fmt.Printf("%sDifficulty    :%s %d\n", BoldCyan, Reset, block.Difficulty)
+				if block.ExtraData != "" {
+					fmt.Printf("%sExtra data    :%s %q\n", BoldCyan, Reset, block.ExtraData)
+				}
+				printBlockSize(cfg, block)
+			}
+			if job.Status == jobFailed {
+				fmt.Println(Red+"Error menambahkan blok:"+Reset, job.Error)
+			}
+			fmt.Printf("%sWaktu         :%s %s\n", BoldCyan, Reset, elapsed)
+
+		case "2":
+			// Tampilkan seluruh blockchain
+			if chain.Len() == 0 {
+				fmt.Println(Yellow + "Blockchain masih kosong." + Reset)
+			} else {
+				displayBlockchain(cfg, chain.Blocks())
+			}
+
+		case "3":
+			// Set tingkat kesulitan
+			fmt.Print(BoldCyan + "Masukkan tingkat kesulitan baru (jumlah nol di awal hash): " + Reset)
+			difficultyInput, _ := reader.ReadString('\n')
+			difficultyInput = strings.TrimSpace(difficultyInput)
+			newDifficulty, err := strconv.Atoi(difficultyInput)
+			if err != nil || newDifficulty < 0 || newDifficulty > 64 {
+				fmt.Println(Red + "Tingkat kesulitan harus berupa angka antara 0 dan 64." + Reset)
+				continue
+			}
+			if err := chain.SetDifficulty(newDifficulty); err != nil {
+				fmt.Println(Red+"Error:"+Reset, err)
+				continue
+			}
+			fmt.Printf(Green+"Tingkat kesulitan berhasil diubah menjadi %d.\n"+Reset, newDifficulty)
+			warnSlowDifficulty(cfg, newDifficulty)
+
+		case "4":
+			// Validasi Blockchain
+			fmt.Println(BoldYellow + "Memvalidasi blockchain..." + Reset)
+			isBlockchainValid(cfg, chain.Blocks())
+
+		case "5":
+			// Keluar dari program
+			fmt.Println(Yellow + "Keluar dari program." + Reset)
+			return
+
+		default:
+			fmt.Println(Red + "Opsi tidak valid. Silakan pilih opsi yang tersedia." + Reset)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDecodeBlock(t *testing.T) {
+	fixture, err := os.ReadFile(filepath.Join("testdata", "chain", "block1.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    Block
+		wantErr string // Kosong berarti decode harus berhasil
+	}{
+		{
+			name:  "stored block file",
+			input: string(fixture),
+			want:  Block{Index: 1, Timestamp: "2024-01-01T00:00:30Z", Data: "bob koin 847", Nonce: 1504, Hash: "000fa2235594b8e90779e839c2419bae93d0a5909c39ec2b5c39fd6be35d8453", PreviousHash: "000a5bc3cdd5115effd26317f49c9f7296a5263e8e118b80970423a8fb4ade4a", Difficulty: 3},
+		},
+		{
+			name:  "compact json with CRLF line ending",
+			input: `{"index":2,"data":"x","difficulty":64,"extra_data":"graffiti"}` + "\r\n",
+			want:  Block{Index: 2, Data: "x", Difficulty: 64, ExtraData: "graffiti"},
+		},
+		{
+			name:  "unknown fields are ignored",
+			input: `{"index":3,"miner":"alice"}`,
+			want:  Block{Index: 3},
+		},
+		{name: "empty input", input: "", wantErr: "EOF"},
+		{name: "not json", input: "block1", wantErr: "invalid character"},
+		{name: "json array", input: `[{"index":1}]`, wantErr: "cannot unmarshal array"},
+		{name: "wrong field type", input: `{"index":"1"}`, wantErr: "cannot unmarshal string"},
+		{name: "negative nonce", input: `{"nonce":-1}`, wantErr: "cannot unmarshal number -1"},
+		{name: "truncated object", input: string(fixture[:len(fixture)/2]), wantErr: "unexpected EOF"},
+		{name: "second block after the first", input: `{"index":1}{"index":2}`, wantErr: "unexpected data after block"},
+		{name: "trailing garbage", input: `{"index":1} x`, wantErr: "unexpected data after block"},
+		{name: "negative difficulty", input: `{"difficulty":-1}`, wantErr: "invalid difficulty -1"},
+		{name: "difficulty beyond hash length", input: `{"difficulty":65}`, wantErr: "invalid difficulty 65"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block, err := decodeBlock([]byte(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("decodeBlock error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeBlock: %v", err)
+			}
+			if block.Index != tt.want.Index || block.Timestamp != tt.want.Timestamp || block.Data != tt.want.Data ||
+				block.Nonce != tt.want.Nonce || block.Hash != tt.want.Hash || block.PreviousHash != tt.want.PreviousHash ||
+				block.Difficulty != tt.want.Difficulty || block.ExtraData != tt.want.ExtraData {
+				t.Errorf("decodeBlock = %+v, want %+v", block, tt.want)
+			}
+		})
+	}
+}
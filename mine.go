@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// findParent returns the block whose hash is ref, or whose index is ref when ref is a number
+func findParent(blockchain []Block, ref string) (Block, error) {
+	for _, block := range blockchain {
+		if block.Hash == ref {
+			return block, nil
+		}
+	}
+	if index, err := strconv.Atoi(ref); err == nil && index >= 0 && index < len(blockchain) {
+		return blockchain[index], nil
+	}
+	return Block{}, fmt.Errorf("blok %q tidak ditemukan di blockchain lokal", ref)
+}
+
+// runMineCommand implements "mine [-parent hash|index] [-extra-data text] [-out file] <data>": it mines one block
+// on a chosen parent, so forks can be built on purpose. A block on the tip is appended; a block
+// on an older parent cannot be stored next to the canonical chain and is written out instead,
+// ready for submitblock on a node whose tip is that parent.
+func runMineCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("mine", flag.ContinueOnError)
+	parentRef := fs.String("parent", "", "Hash atau index blok yang diperpanjang (default tip)")
+	out := fs.String("out", "", "Tulis blok ke file ini (- untuk stdout) alih-alih menyimpannya")
+	extraData := fs.String("extra-data", "", "Pesan miner (maksimal 32 byte) yang disertakan di blok dan ikut di-hash")
+	validatorDir := fs.String("validators", "", "Direktori key validator untuk memfinalisasi blok pada konsensus hybrid")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("penggunaan: mine [-parent hash|index] [-extra-data text] [-out file] <data>")
+	}
+
+	cfg, blockchain, err := loadChain(store)
+	if err != nil {
+		return err
+	}
+	if len(blockchain) == 0 {
+		return fmt.Errorf("blockchain lokal kosong")
+	}
+	if err := checkPayload(store, fs.Arg(0)); err != nil {
+		return err
+	}
+	if err := validExtraData(*extraData); err != nil {
+		return err
+	}
+	var validatorKeys map[string]ed25519.PrivateKey
+	if *validatorDir != "" {
+		if validatorKeys, _, err = loadValidatorKeys(*validatorDir); err != nil {
+			return err
+		}
+	}
+
+	tip := blockchain[len(blockchain)-1]
+	parent := tip
+	if *parentRef != "" {
+		if parent, err = findParent(blockchain, *parentRef); err != nil {
+			return err
+		}
+	}
+	if *out == "" && parent.Hash != tip.Hash {
+		return fmt.Errorf("blok %d bukan tip (%d); fork belum didukung penyimpanan, gunakan -out", parent.Index, tip.Index)
+	}
+
+	// Difficulty dan aturan validasi dihitung dari sejarah sampai parent, seperti yang dilihat
+	// node yang tip-nya adalah parent tersebut
+	history := blockchain[:parent.Index+1]
+	difficulty := newChain(cfg, store, history, parent.Difficulty).Difficulty()
+	if err := waitForMedianTimePast(context.Background(), cfg, history); err != nil {
+		return err
+	}
+	fmt.Printf(BoldYellow+"Mining blok %d di atas blok %d (%s), difficulty %d...\n"+Reset, parent.Index+1, parent.Index, parent.Hash, difficulty)
+	block, err := mineBlockResumable(context.Background(), cfg, fs.Arg(0), *extraData, nil, parent, difficulty, &consoleMiningObserver{}, nil, nil)
+	if err != nil {
+		return err
+	}
+	finalizeBlock(cfg, validatorKeys, &block)
+	candidate := append(history[:len(history):len(history)], block)
+	if rule, err := validateBlock(cfg, candidate, len(candidate)-1); err != nil {
+		return fmt.Errorf("blok hasil mining ditolak oleh aturan %s: %v", rule, err)
+	}
+
+	if *out == "" {
+		if err := submitBlock(newChain(cfg, store, blockchain, tip.Difficulty), block); err != nil {
+			return err
+		}
+		fmt.Printf(Green+"Blok %d (%s) disimpan.\n"+Reset, block.Index, block.Hash)
+		return nil
+	}
+
+	data, err := json.MarshalIndent(block, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if *out == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		return err
+	}
+	fmt.Printf(Green+"Blok %d (%s) ditulis ke %s.\n"+Reset, block.Index, block.Hash, *out)
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// miningStateFile stores the search state of interrupted mining in the blocks directory
+const miningStateFile = "mining-state.json"
+
+// miningCheckpointInterval is how often the search state is saved while mining, so even a
+// crash loses at most this much work
+const miningCheckpointInterval = 5 * time.Second
+
+// miningState is the candidate block and the nonce each worker has reached
+type miningState struct {
+	Index        int       `json:"index"`
+	PreviousHash string    `json:"previous_hash"`
+	Data         string    `json:"data"`
+	Difficulty   int       `json:"difficulty"`
+	Next         []uint64  `json:"next"` // Nonce berikutnya per worker; worker i melangkah len(Next)
+	Updated      time.Time `json:"updated"`
+}
+
+// attempts returns how many nonces the workers have already tried
+func (s *miningState) attempts() uint64 {
+	var total uint64
+	step := uint64(len(s.Next))
+	for i, nonce := range s.Next {
+		if nonce > uint64(i) {
+			total += (nonce - uint64(i)) / step
+		}
+	}
+	return total
+}
+
+// matches reports whether the state continues the search for the block after tip at difficulty
+func (s *miningState) matches(tip Block, difficulty int) bool {
+	return s.PreviousHash == tip.Hash && s.Index == tip.Index+1 && s.Difficulty == difficulty && len(s.Next) > 0
+}
+
+// loadMiningState reads the saved search state; it returns nil when there is none
+func loadMiningState(store BlockStore) (*miningState, error) {
+	data, err := store.ReadFile(miningStateFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state miningState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("%s: %w", miningStateFile, err)
+	}
+	return &state, nil
+}
+
+// saveMiningState writes the search state atomically
+func saveMiningState(store BlockStore, state *miningState) error {
+	state.Updated = time.Now()
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return store.WriteFile(miningStateFile, append(data, '\n'), 0644)
+}
+
+// clearMiningState removes the saved search state once it is no longer needed
+func clearMiningState(store BlockStore) {
+	store.Remove(miningStateFile)
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// nodeShutdownTimeout is how long Stop waits for API requests in flight before closing them
+const nodeShutdownTimeout = 5 * time.Second
+
+// NodeConfig holds the runtime options of a node; the chain parameters come from genesis.json
+type NodeConfig struct {
+	Strategy  MinerStrategy // nil berarti honest
+	Hasher    string        // Perintah hasher eksternal, kosong berarti mining di proses ini
+	ExtraData string        // Graffiti miner di setiap blok yang di-mining node ini
+	Webhooks  []webhook
+	Schedules []blockSchedule
+	APIAddr   string // Kosong berarti tanpa REST API
+	TLSCert   string
+	TLSKey    string
+	APIKeys   *apiKeyStore // nil berarti tanpa autentikasi
+	APIRate   float64      // Request per detik per IP, 0 berarti tanpa batas
+	APIBurst  int
+	ReadOnly  bool   // Tanpa mining; REST API hanya melayani endpoint baca
+	Discovery bool   // Umumkan REST API ke jaringan lokal
+	Pprof     bool   // Endpoint net/http/pprof untuk admin di REST API
+	OTLP      string // Basis URL collector OTLP/HTTP untuk jejak blok, kosong berarti nonaktif
+
+	ValidatorKeys map[string]ed25519.PrivateKey // Key validator lokal untuk memfinalisasi blok pada konsensus hybrid
+
+	NotifyAfter time.Duration // Beri tahu saat mining yang lebih lama dari ini selesai, 0 berarti nonaktif
+	Notify      string        // bell, desktop, atau both
+
+	SnapshotEvery int // Snapshot chain setiap sekian blok, 0 berarti nonaktif
+	SnapshotKeep  int // Jumlah snapshot terbaru yang disimpan
+
+	Backup         string // Direktori atau s3://bucket/prefix untuk backup berkala, kosong berarti nonaktif
+	BackupInterval time.Duration
+}
+
+// Node wires a loaded chain to the mining queue, webhooks, REST API and discovery, so the
+// CLI and other code can run a node with Start and Stop. Every node keeps its own store,
+// validator keys, genesis config and exporter, so one process can host several chains.
+type Node struct {
+	Chain     *Chain
+	Queue     *miningQueue
+	Identity  *nodeIdentity
+	Webhooks  *webhookDispatcher
+	Scheduler *blockScheduler
+
+	config NodeConfig
+	server *apiServer
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewNode prepares a node for chain without starting anything
+func NewNode(chain *Chain, config NodeConfig) (*Node, error) {
+	if config.Strategy == nil {
+		config.Strategy = honestStrategy{}
+	}
+	if config.ReadOnly && config.APIAddr == "" {
+		return nil, fmt.Errorf("mode read-only membutuhkan alamat REST API")
+	}
+
+	if config.Backup != "" && config.BackupInterval <= 0 {
+		return nil, fmt.Errorf("interval backup harus lebih dari 0")
+	}
+
+	// Identitas node tetap sama antar restart sehingga client dan node lain dapat mengenalinya
+	identity, err := loadNodeIdentity(chain.Store())
+	if err != nil {
+		return nil, fmt.Errorf("identitas node: %w", err)
+	}
+
+	if err := validExtraData(config.ExtraData); err != nil {
+		return nil, err
+	}
+
+	queue := newMiningQueue(chain, config.Strategy)
+	queue.extra = config.ExtraData
+	queue.validatorKeys = config.ValidatorKeys
+	if config.Notify == "" {
+		config.Notify = notifyBoth
+	}
+	if queue.notifier, err = newMiningNotifier(config.NotifyAfter, config.Notify); err != nil {
+		return nil, err
+	}
+	n := &Node{
+		Chain:     chain,
+		Queue:     queue,
+		Identity:  identity,
+		Webhooks:  &webhookDispatcher{hooks: config.Webhooks},
+		Scheduler: newBlockScheduler(queue, config.Schedules),
+		config:    config,
+	}
+	// REST API juga tersedia lewat Handler untuk node tanpa alamat sendiri
+	n.server = newAPIServer(chain, n.Queue, config.APIKeys, identity)
+	n.server.webhooks = n.Webhooks
+	n.server.schedules = n.Scheduler
+	n.server.readOnly = config.ReadOnly
+	n.server.pprof = config.Pprof
+	if config.APIRate > 0 {
+		n.server.limiter = newRateLimiter(config.APIRate, config.APIBurst)
+	}
+	return n, nil
+}
+
+// Handler returns the REST API of the node, e.g. to serve several nodes under prefixes of one server
+func (n *Node) Handler() http.Handler {
+	return n.server.limiter.middleware(n.server.routes())
+}
+
+// Start runs the node in the background until ctx is cancelled or Stop is called; it returns
+// once the REST API is listening
+func (n *Node) Start(ctx context.Context) error {
+	if n.cancel != nil {
+		return fmt.Errorf("node sudah berjalan")
+	}
+
+	// Port REST API dibuka lebih dulu agar kesalahan alamat langsung dilaporkan
+	var listener net.Listener
+	if n.config.APIAddr != "" {
+		var err error
+		if listener, err = net.Listen("tcp", n.config.APIAddr); err != nil {
+			return fmt.Errorf("REST API: %w", err)
+		}
+	}
+	if n.config.Hasher != "" && !n.config.ReadOnly {
+		hasher, err := startExternalHasher(n.config.Hasher)
+		if err != nil {
+			if listener != nil {
+				listener.Close()
+			}
+			return fmt.Errorf("hasher eksternal: %w", err)
+		}
+		n.Queue.hasher = hasher
+	}
+
+	ctx, n.cancel = context.WithCancel(ctx)
+	n.goRun(func() { n.Webhooks.run(ctx, &n.Chain.events) })
+	if n.config.OTLP != "" {
+		exporter := newOTLPExporter(n.config.OTLP, n.Identity.ID)
+		n.Queue.exporter = exporter
+		n.goRun(func() { exporter.run(ctx) })
+	}
+	if !n.config.ReadOnly {
+		n.goRun(func() { n.Scheduler.run(ctx, &n.Chain.events) })
+	}
+	if n.config.Backup != "" {
+		target, err := openBackupTarget(n.config.Backup)
+		store, ok := onDiskStore(n.Chain.Store())
+		switch {
+		case err != nil:
+			fmt.Printf(Yellow+"Backup berkala dinonaktifkan: %v\n"+Reset, err)
+		case !ok:
+			fmt.Println(Yellow + "Backup berkala dinonaktifkan: chain tidak disimpan di disk." + Reset)
+		default:
+			n.goRun(func() { runBackups(ctx, store, target, n.config.BackupInterval) })
+		}
+	}
+	// Snapshot hanya dibuat untuk chain di disk yang dapat ditulis
+	if store, ok := n.Chain.Store().(fileBlockStore); ok && n.config.SnapshotEvery > 0 && !n.config.ReadOnly {
+		n.goRun(func() { runSnapshots(ctx, n.Chain, store, n.config.SnapshotEvery, max(n.config.SnapshotKeep, 1)) })
+	}
+	if !n.config.ReadOnly {
+		n.goRun(func() { n.Queue.run(ctx) })
+	}
+	if listener != nil {
+		n.goRun(func() {
+			if err := n.server.serve(ctx, listener, n.config.TLSCert, n.config.TLSKey); err != nil {
+				fmt.Println(Red+"Error REST API:"+Reset, err)
+			}
+		})
+		if n.config.Discovery {
+			if err := announce(ctx, n.Chain, n.Identity, n.config.APIAddr, n.config.TLSCert != ""); err != nil {
+				fmt.Println(Yellow+"Peringatan: node tidak dapat diumumkan ke jaringan lokal:"+Reset, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (n *Node) goRun(f func()) {
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		f()
+	}()
+}
+
+// Stop cancels the running mining job, shuts the REST API down and waits for the node to finish
+func (n *Node) Stop() {
+	if n.cancel == nil {
+		return
+	}
+	for _, job := range n.Queue.Jobs() {
+		n.Queue.Cancel(job.ID)
+	}
+	n.cancel()
+	n.wg.Wait()
+	if n.Queue.hasher != nil {
+		n.Queue.hasher.Close()
+	}
+	n.cancel = nil
+}
+
+// serve serves the API on listener until ctx is cancelled; TLS is used when certFile and keyFile are set
+func (s *apiServer) serve(ctx context.Context, listener net.Listener, certFile, keyFile string) error {
+	server := &http.Server{Handler: s.limiter.middleware(s.routes())}
+	go func() {
+		<-ctx.Done()
+		// Stream SSE tidak pernah selesai sendiri, jadi koneksi yang tersisa ditutup paksa
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), nodeShutdownTimeout)
+		defer cancel()
+		if server.Shutdown(shutdownCtx) != nil {
+			server.Close()
+		}
+	}()
+
+	var err error
+	if certFile != "" && keyFile != "" {
+		err = server.ServeTLS(listener, certFile, keyFile)
+	} else {
+		err = server.Serve(listener)
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
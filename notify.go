@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// Cara memberi tahu pengguna saat mining yang lama selesai
+const (
+	notifyBell    = "bell"
+	notifyDesktop = "desktop"
+	notifyBoth    = "both"
+)
+
+// miningNotifier tells the user when a job that mined longer than after finishes, so a
+// high-difficulty block can be left running in the background
+type miningNotifier struct {
+	after time.Duration
+	mode  string
+}
+
+// newMiningNotifier validates the mode; a zero threshold disables notifications
+func newMiningNotifier(after time.Duration, mode string) (*miningNotifier, error) {
+	switch mode {
+	case notifyBell, notifyDesktop, notifyBoth:
+	default:
+		return nil, fmt.Errorf("mode notifikasi tidak dikenal: %q (gunakan %s, %s, atau %s)", mode, notifyBell, notifyDesktop, notifyBoth)
+	}
+	if after <= 0 {
+		return nil, nil
+	}
+	return &miningNotifier{after: after, mode: mode}, nil
+}
+
+// jobFinished notifies about a finished job if it mined for at least the threshold
+func (n *miningNotifier) jobFinished(job miningJob, elapsed time.Duration) {
+	if n == nil || elapsed < n.after || job.Status == jobQueued {
+		return
+	}
+	title := "Mining selesai"
+	body := fmt.Sprintf("Pekerjaan #%d selesai dalam %v", job.ID, elapsed.Round(time.Second))
+	if job.Block != nil {
+		body = fmt.Sprintf("Blok %d (difficulty %d) selesai dalam %v", job.Block.Index, job.Block.Difficulty, elapsed.Round(time.Second))
+	}
+	if job.Status != jobDone {
+		title = "Mining " + job.Status
+		body = fmt.Sprintf("Pekerjaan #%d %s setelah %v", job.ID, job.Status, elapsed.Round(time.Second))
+		if job.Error != "" {
+			body += ": " + job.Error
+		}
+	}
+
+	if n.mode == notifyBell || n.mode == notifyBoth {
+		fmt.Print("\a")
+	}
+	if n.mode == notifyDesktop || n.mode == notifyBoth {
+		if err := sendDesktopNotification(title, body); err != nil {
+			fmt.Printf(Yellow+"Notifikasi desktop gagal: %v\n"+Reset, err)
+		}
+	}
+}
+
+// sendDesktopNotification uses the notification tool of the platform
+func sendDesktopNotification(title, body string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("osascript", "-e", "display notification "+strconv.Quote(body)+" with title "+strconv.Quote(title))
+	case "windows":
+		script := "Add-Type -AssemblyName System.Windows.Forms; $n = New-Object System.Windows.Forms.NotifyIcon; " +
+			"$n.Icon = [System.Drawing.SystemIcons]::Information; $n.Visible = $true; " +
+			"$n.ShowBalloonTip(10000, " + powershellQuote(title) + ", " + powershellQuote(body) + ", 'Info'); Start-Sleep -Seconds 10; $n.Dispose()"
+		cmd = exec.Command("powershell", "-NoProfile", "-Command", script)
+		return cmd.Start() // Balon harus tetap hidup beberapa detik; jangan tunggu
+	default:
+		cmd = exec.Command("notify-send", "--app-name=blockchain-simulation", title, body)
+	}
+	return cmd.Run()
+}
+
+// powershellQuote quotes s as a single-quoted PowerShell string
+func powershellQuote(s string) string {
+	quoted := "'"
+	for _, r := range s {
+		if r == '\'' {
+			quoted += "'"
+		}
+		quoted += string(r)
+	}
+	return quoted + "'"
+}
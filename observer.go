@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// MiningObserver receives miner telemetry so mining does not depend on where progress is shown
+type MiningObserver interface {
+	// MiningProgress reports a nonce currently being tried; called periodically, not for every attempt
+	MiningProgress(nonce uint64)
+	// MiningSolved reports the block whose hash meets the difficulty
+	MiningSolved(block Block, elapsed time.Duration)
+	// MiningCancelled reports that mining stopped before a solution was found
+	MiningCancelled(elapsed time.Duration)
+}
+
+// consoleMiningObserver shows progress on a single, continuously updated terminal line
+type consoleMiningObserver struct {
+	lastNonce uint64
+}
+
+func (o *consoleMiningObserver) MiningProgress(nonce uint64) {
+	if nonce > o.lastNonce {
+		fmt.Printf("\r%sNonce sedang diperiksa: %d%s", BoldCyan, nonce, Reset)
+		o.lastNonce = nonce
+	}
+}
+
+func (o *consoleMiningObserver) MiningSolved(Block, time.Duration) {
+	fmt.Println() // Menambahkan newline setelah mining selesai
+}
+
+func (o *consoleMiningObserver) MiningCancelled(elapsed time.Duration) {
+	fmt.Printf("\n"+Yellow+"Mining dibatalkan setelah %s.\n"+Reset, elapsed)
+}
+
+// logMiningObserver writes mining events to the standard logger, for headless mining such as the API
+type logMiningObserver struct {
+	prefix string
+}
+
+func (o logMiningObserver) MiningProgress(uint64) {}
+
+func (o logMiningObserver) MiningSolved(block Block, elapsed time.Duration) {
+	log.Printf("%sblok %d ditemukan dengan nonce %d dalam %s", o.prefix, block.Index, block.Nonce, elapsed)
+}
+
+func (o logMiningObserver) MiningCancelled(elapsed time.Duration) {
+	log.Printf("%smining dibatalkan setelah %s", o.prefix, elapsed)
+}
+
+// multiMiningObserver forwards every event to several observers
+type multiMiningObserver []MiningObserver
+
+func (m multiMiningObserver) MiningProgress(nonce uint64) {
+	for _, o := range m {
+		o.MiningProgress(nonce)
+	}
+}
+
+func (m multiMiningObserver) MiningSolved(block Block, elapsed time.Duration) {
+	for _, o := range m {
+		o.MiningSolved(block, elapsed)
+	}
+}
+
+func (m multiMiningObserver) MiningCancelled(elapsed time.Duration) {
+	for _, o := range m {
+		o.MiningCancelled(elapsed)
+	}
+}
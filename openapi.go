@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// pathParameter matches {name} path parameters in route patterns
+var pathParameter = regexp.MustCompile(`\{([^}]+)\}`)
+
+// openAPISpec builds an OpenAPI v3 document from the route table
+func (s *apiServer) openAPISpec() map[string]any {
+	paths := make(map[string]map[string]any)
+	for _, route := range s.routeTable() {
+		operation := map[string]any{
+			"summary": route.Summary,
+			"responses": map[string]any{
+				"200": map[string]any{"description": "OK", "content": map[string]any{"application/json": map[string]any{}}},
+			},
+		}
+
+		var parameters []map[string]any
+		for _, match := range pathParameter.FindAllStringSubmatch(route.Path, -1) {
+			parameters = append(parameters, map[string]any{"name": match[1], "in": "path", "required": true, "schema": map[string]string{"type": "string"}})
+		}
+		if parameters != nil {
+			operation["parameters"] = parameters
+		}
+
+		if route.Body != "" {
+			var example any
+			json.Unmarshal([]byte(route.Body), &example)
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content":  map[string]any{"application/json": map[string]any{"schema": map[string]string{"type": "object"}, "example": example}},
+			}
+		}
+
+		// Tanpa API key semua endpoint terbuka, jadi security hanya dicantumkan jika autentikasi aktif
+		if s.keys != nil && !route.Public {
+			operation["security"] = []map[string][]string{{"bearerAuth": {}}}
+			operation["description"] = "Membutuhkan permission " + route.Permission.String()
+			operation["responses"].(map[string]any)["401"] = map[string]any{"description": "Autentikasi diperlukan"}
+			operation["responses"].(map[string]any)["403"] = map[string]any{"description": "Permission tidak cukup"}
+		}
+
+		if paths[route.Path] == nil {
+			paths[route.Path] = make(map[string]any)
+		}
+		paths[route.Path][strings.ToLower(route.Method)] = operation
+	}
+
+	spec := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Blockchain Simulation REST API",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+	}
+	if s.keys != nil {
+		spec["components"] = map[string]any{
+			"securitySchemes": map[string]any{"bearerAuth": map[string]string{"type": "http", "scheme": "bearer"}},
+		}
+	}
+	return spec
+}
+
+func (s *apiServer) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.openAPISpec())
+}
+
+// docsTemplate renders the route table as a self-contained page, so it also works without internet access
+var docsTemplate = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html lang="id">
+<head><meta charset="utf-8"><title>Blockchain Simulation REST API</title>
+<style>body{font-family:sans-serif;max-width:60em;margin:2em auto}td,th{padding:.3em .8em;text-align:left;vertical-align:top}code{background:#eee;padding:0 .2em}</style>
+</head>
+<body>
+<h1>Blockchain Simulation REST API</h1>
+<p>Spesifikasi OpenAPI v3: <a href="/openapi.json">/openapi.json</a> (dapat dipakai generator client seperti openapi-generator).</p>
+<table>
+<tr><th>Method</th><th>Path</th><th>Permission</th><th>Deskripsi</th></tr>
+{{range .Routes}}<tr><td><code>{{.Method}}</code></td><td><code>{{.Path}}</code></td><td>{{if or .Public (not $.Auth)}}-{{else}}{{.Permission}}{{end}}</td><td>{{.Summary}}{{if .Body}}<br>Body: <code>{{.Body}}</code>{{end}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+func (s *apiServer) handleDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	docsTemplate.Execute(w, map[string]any{"Routes": s.routeTable(), "Auth": s.keys != nil})
+}
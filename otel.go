@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Span OTLP dikirim berkelompok agar exporter tidak memperlambat mining
+const (
+	otlpFlushInterval = 2 * time.Second
+	otlpQueueSize     = 1024
+)
+
+// traceSpan is one finished span of a block trace
+type traceSpan struct {
+	traceID  string
+	spanID   string
+	parentID string
+	name     string
+	start    time.Time
+	end      time.Time
+	attrs    []string // Pasangan key, value
+}
+
+// blockTrace follows one block from template to broadcast as an OpenTelemetry trace; a nil
+// trace records nothing, so callers need no checks when tracing is off
+type blockTrace struct {
+	mu     sync.Mutex
+	id     string
+	root   traceSpan
+	spans  []traceSpan
+	export *otlpExporter
+}
+
+// randomHex returns n random bytes as hex, the id format of OTLP/JSON
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// newBlockTrace starts the root span of a new trace exported by exporter; it returns nil, a
+// trace that records nothing, when exporter is nil
+func newBlockTrace(exporter *otlpExporter, name string, attrs ...string) *blockTrace {
+	if exporter == nil {
+		return nil
+	}
+	id := randomHex(16)
+	return &blockTrace{
+		id:     id,
+		root:   traceSpan{traceID: id, spanID: randomHex(8), name: name, start: time.Now(), attrs: attrs},
+		export: exporter,
+	}
+}
+
+// start begins a child span of the root; the returned function ends it with extra attributes
+func (t *blockTrace) start(name string) func(attrs ...string) {
+	if t == nil {
+		return func(...string) {}
+	}
+	span := traceSpan{traceID: t.id, spanID: randomHex(8), parentID: t.root.spanID, name: name, start: time.Now()}
+	return func(attrs ...string) {
+		span.end = time.Now()
+		span.attrs = attrs
+		t.mu.Lock()
+		t.spans = append(t.spans, span)
+		t.mu.Unlock()
+	}
+}
+
+// finish ends the root span and hands the whole trace to the exporter
+func (t *blockTrace) finish(attrs ...string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root.end = time.Now()
+	t.root.attrs = append(t.root.attrs, attrs...)
+	t.export.enqueue(append([]traceSpan{t.root}, t.spans...))
+}
+
+// otlpExporter sends spans to an OTLP/HTTP collector using the JSON encoding, so standard
+// tooling (Jaeger, Tempo, the OpenTelemetry Collector) can show them without an SDK dependency
+type otlpExporter struct {
+	endpoint string // Basis URL collector, misalnya http://localhost:4318
+	nodeID   string
+	spans    chan traceSpan
+	client   *http.Client
+}
+
+// newOTLPExporter creates an exporter for the collector at endpoint
+func newOTLPExporter(endpoint, nodeID string) *otlpExporter {
+	return &otlpExporter{
+		endpoint: strings.TrimRight(endpoint, "/"),
+		nodeID:   nodeID,
+		spans:    make(chan traceSpan, otlpQueueSize),
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// enqueue queues spans for export and drops them when the queue is full
+func (e *otlpExporter) enqueue(spans []traceSpan) {
+	for _, span := range spans {
+		select {
+		case e.spans <- span:
+		default:
+		}
+	}
+}
+
+// run exports queued spans every otlpFlushInterval until ctx is cancelled, then flushes the rest
+func (e *otlpExporter) run(ctx context.Context) {
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+	var batch []traceSpan
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.send(batch); err != nil {
+			fmt.Printf(Yellow+"Export OTLP gagal: %v\n"+Reset, err)
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case span := <-e.spans:
+			batch = append(batch, span)
+		case <-ticker.C:
+			flush()
+		case <-ctx.Done():
+			for len(e.spans) > 0 {
+				batch = append(batch, <-e.spans)
+			}
+			flush()
+			return
+		}
+	}
+}
+
+// otlpAttributes converts key, value pairs to OTLP KeyValue objects
+func otlpAttributes(pairs []string) []map[string]any {
+	attrs := make([]map[string]any, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		attrs = append(attrs, map[string]any{"key": pairs[i], "value": map[string]string{"stringValue": pairs[i+1]}})
+	}
+	return attrs
+}
+
+// send posts one ExportTraceServiceRequest to /v1/traces
+func (e *otlpExporter) send(batch []traceSpan) error {
+	spans := make([]map[string]any, len(batch))
+	for i, span := range batch {
+		spans[i] = map[string]any{
+			"traceId":           span.traceID,
+			"spanId":            span.spanID,
+			"parentSpanId":      span.parentID,
+			"name":              span.name,
+			"kind":              1, // SPAN_KIND_INTERNAL
+			"startTimeUnixNano": strconv.FormatInt(span.start.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(span.end.UnixNano(), 10),
+			"attributes":        otlpAttributes(span.attrs),
+		}
+	}
+	request := map[string]any{
+		"resourceSpans": []map[string]any{{
+			"resource": map[string]any{"attributes": otlpAttributes([]string{"service.name", "blockchain-simulation", "service.instance.id", e.nodeID})},
+			"scopeSpans": []map[string]any{{
+				"scope": map[string]string{"name": "blockchain-simulation"},
+				"spans": spans,
+			}},
+		}},
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	resp, err := e.client.Post(e.endpoint+"/v1/traces", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("collector membalas HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,443 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Jenis payload terstruktur yang dikenali di field Data
+const (
+	payloadKV     = "kv"
+	payloadJSON   = "json"
+	payloadDigest = "digest"
+)
+
+// payload is the structured envelope an application may store in Block.Data instead of free
+// text: {"type": "kv", "data": {...}}, {"type": "json", "schema": "...", "data": {...}} or
+// {"type": "digest", "algorithm": "sha256", "digest": "...", "name": "...", "size": N}.
+// Data that is not such an envelope stays plain text.
+type payload struct {
+	Type      string          `json:"type"`
+	Schema    string          `json:"schema,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Algorithm string          `json:"algorithm,omitempty"`
+	Digest    string          `json:"digest,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Size      int64           `json:"size,omitempty"`
+}
+
+// payloadSchema is the JSON Schema subset used to validate json payloads
+type payloadSchema struct {
+	Required   []string `json:"required"`
+	Properties map[string]struct {
+		Type string `json:"type"`
+	} `json:"properties"`
+	AdditionalProperties *bool `json:"additionalProperties"`
+}
+
+// schemaNamePattern keeps schema names usable as file names
+var schemaNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// schemaFile returns the store record holding the schema called name
+func schemaFile(name string) string {
+	return "schema-" + name + ".json"
+}
+
+// isSchemaFile reports whether base is the name of a schema record
+func isSchemaFile(base string) bool {
+	name, ok := strings.CutPrefix(base, "schema-")
+	if !ok {
+		return false
+	}
+	name, ok = strings.CutSuffix(name, ".json")
+	return ok && schemaNamePattern.MatchString(name)
+}
+
+// schemaFiles returns the names of the schema records in the store directory
+func (s fileBlockStore) schemaFiles() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && isSchemaFile(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// parsePayload decodes data as a payload envelope; plain text returns false
+func parsePayload(data string) (payload, bool) {
+	if !strings.HasPrefix(strings.TrimSpace(data), "{") {
+		return payload{}, false
+	}
+	var p payload
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		return payload{}, false
+	}
+	switch p.Type {
+	case payloadKV, payloadJSON, payloadDigest:
+		return p, true
+	}
+	return payload{}, false
+}
+
+// encode renders the payload as block data
+func (p payload) encode() string {
+	data, _ := json.Marshal(p)
+	return string(data)
+}
+
+// KV returns the records of a kv payload
+func (p payload) KV() (map[string]string, error) {
+	if p.Type != payloadKV {
+		return nil, fmt.Errorf("payload bertipe %s, bukan %s", p.Type, payloadKV)
+	}
+	var records map[string]string
+	if err := json.Unmarshal(p.Data, &records); err != nil {
+		return nil, fmt.Errorf("payload kv harus berupa objek string ke string: %w", err)
+	}
+	return records, nil
+}
+
+// validate checks the payload shape and, for json payloads, the schema stored with the chain
+func (p payload) validate(store BlockStore) error {
+	switch p.Type {
+	case payloadKV:
+		_, err := p.KV()
+		return err
+	case payloadDigest:
+		if p.Algorithm != "sha256" {
+			return fmt.Errorf("algoritma digest tidak didukung: %q (gunakan sha256)", p.Algorithm)
+		}
+		if digest, err := hex.DecodeString(p.Digest); err != nil || len(digest) != sha256.Size {
+			return fmt.Errorf("digest sha256 harus berupa 64 karakter hex")
+		}
+		return nil
+	case payloadJSON:
+		var document map[string]any
+		if err := json.Unmarshal(p.Data, &document); err != nil {
+			return fmt.Errorf("payload json harus berupa objek: %w", err)
+		}
+		if p.Schema == "" {
+			return nil
+		}
+		schema, err := loadPayloadSchema(store, p.Schema)
+		if err != nil {
+			return err
+		}
+		return schema.check(document)
+	}
+	return fmt.Errorf("jenis payload tidak dikenal: %q", p.Type)
+}
+
+// loadPayloadSchema reads the schema called name from the chain's store
+func loadPayloadSchema(store BlockStore, name string) (payloadSchema, error) {
+	if !schemaNamePattern.MatchString(name) {
+		return payloadSchema{}, fmt.Errorf("nama schema tidak valid: %q", name)
+	}
+	data, err := store.ReadFile(schemaFile(name))
+	if os.IsNotExist(err) {
+		return payloadSchema{}, fmt.Errorf("schema %q tidak terdaftar; tambahkan dengan payload schema %s <file>", name, name)
+	}
+	if err != nil {
+		return payloadSchema{}, err
+	}
+	var schema payloadSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return payloadSchema{}, fmt.Errorf("schema %s: %w", name, err)
+	}
+	return schema, nil
+}
+
+// jsonType names the JSON Schema type of a decoded value
+func jsonType(value any) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return "null"
+	}
+}
+
+// check validates document against the schema
+func (s payloadSchema) check(document map[string]any) error {
+	for _, field := range s.Required {
+		if _, ok := document[field]; !ok {
+			return fmt.Errorf("field %q wajib ada", field)
+		}
+	}
+	for field, value := range document {
+		property, ok := s.Properties[field]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				return fmt.Errorf("field %q tidak ada di schema", field)
+			}
+			continue
+		}
+		actual := jsonType(value)
+		if property.Type == "integer" && actual == "number" && value.(float64) == float64(int64(value.(float64))) {
+			continue
+		}
+		if property.Type != "" && property.Type != actual {
+			return fmt.Errorf("field %q harus bertipe %s, bukan %s", field, property.Type, actual)
+		}
+	}
+	return nil
+}
+
+// checkPayload validates data that is a payload envelope against the schemas in store; plain
+// text is always accepted
+func checkPayload(store BlockStore, data string) error {
+	p, ok := parsePayload(data)
+	if !ok {
+		return nil
+	}
+	if err := p.validate(store); err != nil {
+		return fmt.Errorf("payload %s tidak valid: %w", p.Type, err)
+	}
+	return nil
+}
+
+// fileDigestPayload hashes the file at path into a digest payload
+func fileDigestPayload(path string) (payload, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return payload{}, err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return payload{}, err
+	}
+	return payload{Type: payloadDigest, Algorithm: "sha256", Digest: hex.EncodeToString(hash.Sum(nil)), Name: filepath.Base(path), Size: size}, nil
+}
+
+// handleGetPayload implements GET /blocks/{index}/payload: the typed payload of a block, or
+// with ?key= a single record of a kv payload
+func (s *apiServer) handleGetPayload(w http.ResponseWriter, r *http.Request) {
+	index, err := strconv.Atoi(r.PathValue("index"))
+	blocks := s.chain.Blocks()
+	if err != nil || index < 0 || index >= len(blocks) {
+		writeError(w, http.StatusNotFound, "blok tidak ditemukan")
+		return
+	}
+	p, ok := parsePayload(blocks[index].Data)
+	if !ok {
+		writeJSON(w, http.StatusOK, map[string]string{"type": "text", "data": blocks[index].Data})
+		return
+	}
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		writeJSON(w, http.StatusOK, p)
+		return
+	}
+	records, err := p.KV()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	value, ok := records[key]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("key %q tidak ada di blok %d", key, index))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"key": key, "value": value})
+}
+
+// runPayloadCommand implements "payload kv|json|digest|schema|show": it builds block data in
+// one of the payload shapes, ready for mine or the API, and reads payloads back
+func runPayloadCommand(store BlockStore, args []string) error {
+	usage := fmt.Errorf("penggunaan: payload kv key=value... | json [-schema name] <file|-> | digest <file> | schema <name> <file> | show <index> [key]")
+	if len(args) == 0 {
+		return usage
+	}
+	cfg, err := loadGenesisConfig(store)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "kv":
+		records := make(map[string]string)
+		for _, arg := range args[1:] {
+			key, value, ok := strings.Cut(arg, "=")
+			if !ok || key == "" {
+				return fmt.Errorf("record harus berbentuk key=value: %q", arg)
+			}
+			records[key] = value
+		}
+		data, _ := json.Marshal(records)
+		return printPayload(cfg, store, payload{Type: payloadKV, Data: data})
+
+	case "json":
+		fs := flag.NewFlagSet("payload json", flag.ContinueOnError)
+		schema := fs.String("schema", "", "Nama schema terdaftar untuk memvalidasi dokumen")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if fs.NArg() != 1 {
+			return usage
+		}
+		var document []byte
+		if fs.Arg(0) == "-" {
+			document, err = io.ReadAll(os.Stdin)
+		} else {
+			document, err = os.ReadFile(fs.Arg(0))
+		}
+		if err != nil {
+			return err
+		}
+		// Dokumen dipadatkan agar tidak memakan ukuran data blok
+		var compact strings.Builder
+		var value any
+		if err := json.Unmarshal(document, &value); err != nil {
+			return fmt.Errorf("dokumen bukan JSON yang valid: %w", err)
+		}
+		encoder := json.NewEncoder(&compact)
+		encoder.SetEscapeHTML(false)
+		encoder.Encode(value)
+		return printPayload(cfg, store, payload{Type: payloadJSON, Schema: *schema, Data: json.RawMessage(strings.TrimSpace(compact.String()))})
+
+	case "digest":
+		if len(args) != 2 {
+			return usage
+		}
+		p, err := fileDigestPayload(args[1])
+		if err != nil {
+			return err
+		}
+		return printPayload(cfg, store, p)
+
+	case "schema":
+		if len(args) != 3 {
+			return usage
+		}
+		if !schemaNamePattern.MatchString(args[1]) {
+			return fmt.Errorf("nama schema tidak valid: %q", args[1])
+		}
+		data, err := os.ReadFile(args[2])
+		if err != nil {
+			return err
+		}
+		var schema payloadSchema
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return fmt.Errorf("%s: %w", args[2], err)
+		}
+		if err := store.WriteFile(schemaFile(args[1]), data, 0644); err != nil {
+			return err
+		}
+		fmt.Printf(Green+"Schema %s terdaftar.\n"+Reset, args[1])
+		return nil
+
+	case "show":
+		if len(args) < 2 || len(args) > 3 {
+			return usage
+		}
+		blockchain, err := loadBlockchain(store)
+		if err != nil {
+			return err
+		}
+		block, err := findParent(blockchain, args[1])
+		if err != nil {
+			return err
+		}
+		p, ok := parsePayload(block.Data)
+		if !ok {
+			fmt.Printf("Blok %d berisi teks biasa: %s\n", block.Index, block.Data)
+			return nil
+		}
+		if len(args) == 3 {
+			records, err := p.KV()
+			if err != nil {
+				return err
+			}
+			value, ok := records[args[2]]
+			if !ok {
+				return fmt.Errorf("key %q tidak ada di blok %d", args[2], block.Index)
+			}
+			fmt.Println(value)
+			return nil
+		}
+		printPayloadDetail(store, p)
+		return nil
+
+	default:
+		return usage
+	}
+}
+
+// printPayload validates p against cfg and the schemas in store and prints its block data on
+// stdout, e.g. for mine "$(payload kv a=1)"
+func printPayload(cfg GenesisConfig, store BlockStore, p payload) error {
+	if err := p.validate(store); err != nil {
+		return err
+	}
+	data := p.encode()
+	if err := cfg.checkDataSize(data); err != nil {
+		return err
+	}
+	// Peringatan ke stderr agar stdout tetap bisa dipakai langsung sebagai data blok
+	if warning := cfg.dataSizeWarning(len(data)); warning != "" {
+		fmt.Fprintln(os.Stderr, Yellow+"Peringatan: "+warning+Reset)
+	}
+	fmt.Println(data)
+	return nil
+}
+
+// printPayloadDetail shows a payload in readable form, checked against the schemas in store
+func printPayloadDetail(store BlockStore, p payload) {
+	fmt.Printf("%sJenis payload :%s %s\n", BoldCyan, Reset, p.Type)
+	switch p.Type {
+	case payloadKV:
+		records, err := p.KV()
+		if err != nil {
+			fmt.Println(Red+"Error:"+Reset, err)
+			return
+		}
+		keys := make([]string, 0, len(records))
+		for key := range records {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("  %s = %s\n", key, records[key])
+		}
+	case payloadJSON:
+		if p.Schema != "" {
+			fmt.Printf("%sSchema        :%s %s\n", BoldCyan, Reset, p.Schema)
+		}
+		fmt.Printf("%sDokumen       :%s %s\n", BoldCyan, Reset, p.Data)
+	case payloadDigest:
+		fmt.Printf("%sFile          :%s %s (%d byte)\n", BoldCyan, Reset, p.Name, p.Size)
+		fmt.Printf("%s%-14s:%s %s\n", BoldCyan, strings.ToUpper(p.Algorithm), Reset, p.Digest)
+	}
+	if err := p.validate(store); err != nil {
+		fmt.Println(Yellow+"Payload tidak valid:"+Reset, err)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckPayload(t *testing.T) {
+	store := newMemoryBlockStore()
+	schema := `{"required": ["sku", "qty"], "properties": {"sku": {"type": "string"}, "qty": {"type": "integer"}, "price": {"type": "number"}}, "additionalProperties": false}`
+	if err := store.WriteFile(schemaFile("order"), []byte(schema), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WriteFile(schemaFile("open"), []byte(`{"properties": {"sku": {"type": "string"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	digest := strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string // Kosong berarti data harus diterima
+	}{
+		{name: "plain text", data: "alice kirim bob 5"},
+		{name: "json that is not an envelope", data: `{"from": "alice"}`},
+		{name: "kv", data: `{"type": "kv", "data": {"owner": "alice"}}`},
+		{name: "kv with a number value", data: `{"type": "kv", "data": {"qty": 5}}`, wantErr: "objek string ke string"},
+		{name: "digest", data: `{"type": "digest", "algorithm": "sha256", "digest": "` + digest + `"}`},
+		{name: "digest with another algorithm", data: `{"type": "digest", "algorithm": "md5", "digest": "` + digest + `"}`, wantErr: "tidak didukung"},
+		{name: "digest of the wrong length", data: `{"type": "digest", "algorithm": "sha256", "digest": "abcd"}`, wantErr: "64 karakter hex"},
+		{name: "json without schema", data: `{"type": "json", "data": {"anything": [1, 2]}}`},
+		{name: "json array", data: `{"type": "json", "data": [1, 2]}`, wantErr: "harus berupa objek"},
+		{name: "json matching schema", data: `{"type": "json", "schema": "order", "data": {"sku": "A-1", "qty": 2, "price": 9.5}}`},
+		{name: "missing required field", data: `{"type": "json", "schema": "order", "data": {"sku": "A-1"}}`, wantErr: `field "qty" wajib ada`},
+		{name: "wrong field type", data: `{"type": "json", "schema": "order", "data": {"sku": 1, "qty": 2}}`, wantErr: `field "sku" harus bertipe string, bukan number`},
+		{name: "fraction for an integer", data: `{"type": "json", "schema": "order", "data": {"sku": "A-1", "qty": 2.5}}`, wantErr: `field "qty" harus bertipe integer`},
+		{name: "field outside a closed schema", data: `{"type": "json", "schema": "order", "data": {"sku": "A-1", "qty": 2, "note": "x"}}`, wantErr: `field "note" tidak ada di schema`},
+		{name: "field outside an open schema", data: `{"type": "json", "schema": "open", "data": {"sku": "A-1", "note": "x"}}`},
+		{name: "unregistered schema", data: `{"type": "json", "schema": "invoice", "data": {}}`, wantErr: `schema "invoice" tidak terdaftar`},
+		{name: "schema name with a path", data: `{"type": "json", "schema": "../node", "data": {}}`, wantErr: "nama schema tidak valid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPayload(store, tt.data)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkPayload: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkPayload error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCategorizeSchemaFiles(t *testing.T) {
+	tests := []struct{ rel, want string }{
+		{schemaFile("order"), "schema"},
+		{"schema-.json", "lainnya"},
+		{filepath.Join(snapshotsDir, "00000002", schemaFile("order")), "snapshot"},
+		{genesisConfigFile, "metadata"},
+	}
+	for _, tt := range tests {
+		if got := categorizeStoreFile(tt.rel); got != tt.want {
+			t.Errorf("categorizeStoreFile(%q) = %q, want %q", tt.rel, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"hash"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Nama algoritma proof-of-work yang dapat dipilih per chain
+const (
+	powSHA256  = "sha256"
+	powMemHard = "memhard"
+)
+
+// defaultPoWMemoryKiB is the scratchpad size of the memory-hard PoW when genesis.json does not set one
+const defaultPoWMemoryKiB = 256
+
+// powHash hashes a block record with the proof-of-work function of the chain
+func powHash(cfg GenesisConfig, record []byte) [32]byte {
+	if cfg.PoW == powMemHard {
+		return memoryHardHash(record, cfg.PoWMemoryKiB)
+	}
+	return sha256.Sum256(record)
+}
+
+// midstateHasher hashes many records that share a prefix: the SHA-256 state after the prefix
+// is computed once and restored for every attempt, so only the blocks holding the nonce and
+// the previous hash are compressed again. Other PoW functions fall back to powHash.
+type midstateHasher struct {
+	cfg      GenesisConfig
+	prefix   string
+	midstate []byte
+	digest   hash.Hash
+	buf      []byte
+}
+
+// newMidstateHasher creates a hasher for the proof-of-work function of cfg
+func newMidstateHasher(cfg GenesisConfig) *midstateHasher {
+	return &midstateHasher{cfg: cfg, digest: sha256.New()}
+}
+
+// hash returns powHash of prefix + nonce + suffix; the midstate is recomputed only when prefix changes
+func (m *midstateHasher) hash(prefix string, nonce uint64, suffix string) [32]byte {
+	m.buf = append(strconv.AppendUint(m.buf[:0], nonce, 10), suffix...)
+	if m.cfg.PoW != powSHA256 {
+		return powHash(m.cfg, append([]byte(prefix), m.buf...))
+	}
+
+	if m.midstate == nil || prefix != m.prefix {
+		m.digest.Reset()
+		m.digest.Write([]byte(prefix))
+		m.midstate, _ = m.digest.(encoding.BinaryMarshaler).MarshalBinary()
+		m.prefix = prefix
+	}
+	m.digest.(encoding.BinaryUnmarshaler).UnmarshalBinary(m.midstate)
+	m.digest.Write(m.buf)
+	var sum [32]byte
+	m.digest.Sum(sum[:0])
+	return sum
+}
+
+// meetsDifficulty reports whether sum starts with difficulty zero hex digits, without hex encoding it
+func meetsDifficulty(sum [32]byte, difficulty int) bool {
+	if difficulty > 2*len(sum) {
+		return false
+	}
+	for i := 0; i < difficulty/2; i++ {
+		if sum[i] != 0 {
+			return false
+		}
+	}
+	return difficulty%2 == 0 || sum[difficulty/2]>>4 == 0
+}
+
+// memoryHardHash is a simplified scrypt-style ROMix over SHA-256: it fills a scratchpad
+// of memoryKiB with a hash chain, then reads it back in a data-dependent order so every
+// attempt needs the whole scratchpad in memory
+func memoryHardHash(record []byte, memoryKiB int) [32]byte {
+	if memoryKiB < 1 {
+		memoryKiB = defaultPoWMemoryKiB
+	}
+	n := memoryKiB * 1024 / sha256.Size
+	scratchpad := make([][32]byte, n)
+
+	x := sha256.Sum256(record)
+	for i := range scratchpad {
+		scratchpad[i] = x
+		x = sha256.Sum256(x[:])
+	}
+
+	for i := 0; i < n; i++ {
+		j := binary.LittleEndian.Uint64(x[:8]) % uint64(n)
+		for k := range x {
+			x[k] ^= scratchpad[j][k]
+		}
+		x = sha256.Sum256(x[:])
+	}
+	return sha256.Sum256(x[:])
+}
+
+// powAlgorithms lists the selectable proof-of-work functions with a short description
+var powAlgorithms = map[string]string{
+	powSHA256:  "SHA-256 biasa (CPU-bound)",
+	powMemHard: "SHA-256 ROMix dengan scratchpad (memory-bound)",
+}
+
+// powAlgorithmNames returns the PoW names in sorted order
+func powAlgorithmNames() []string {
+	names := make([]string, 0, len(powAlgorithms))
+	for name := range powAlgorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Record yang di-hash oleh measureHashrate
+const (
+	benchShort    = iota // Record pendek, mengukur fungsi PoW saja
+	benchHeader          // Record seukuran header blok, di-hash utuh setiap percobaan
+	benchMidstate        // Record seukuran header blok dengan midstate, seperti miner
+)
+
+// measureHashrate hashes the given kind of record with every CPU for duration and returns hashes per second
+func measureHashrate(cfg GenesisConfig, duration time.Duration, kind int) float64 {
+	var total atomic.Uint64
+	var wg sync.WaitGroup
+	deadline := time.Now().Add(duration)
+	start := time.Now()
+
+	for worker := 0; worker < runtime.NumCPU(); worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			record := []byte(fmt.Sprintf("benchmark-%d-", worker))
+			// Prefix seukuran header blok biasa: index, timestamp, dan data
+			prefix := fmt.Sprintf("%d%s%s", worker+1, time.Now().Format(time.RFC3339), strings.Repeat("data blok ", 8))
+			suffix := strings.Repeat("0", 64)
+			hasher := newMidstateHasher(cfg)
+			var nonce uint64
+			for time.Now().Before(deadline) {
+				// Waktu hanya diperiksa setiap beberapa hash agar tidak mendominasi
+				for i := 0; i < 64; i++ {
+					switch kind {
+					case benchShort:
+						powHash(cfg, binary.LittleEndian.AppendUint64(record, nonce))
+					case benchHeader:
+						powHash(cfg, []byte(prefix+strconv.FormatUint(nonce, 10)+suffix))
+					case benchMidstate:
+						hasher.hash(prefix, nonce, suffix)
+					}
+					nonce++
+				}
+				total.Add(64)
+			}
+		}(worker)
+	}
+	wg.Wait()
+	return float64(total.Load()) / time.Since(start).Seconds()
+}
+
+func runBenchCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	duration := fs.Duration("duration", 3*time.Second, "Lama pengukuran per algoritma")
+	memory := fs.Int("memory", defaultPoWMemoryKiB, "Ukuran scratchpad memhard dalam KiB")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	fmt.Println(BoldYellow + "\n=== Benchmark Proof-of-Work ===" + Reset)
+	fmt.Printf("%d CPU, %s per algoritma\n", runtime.NumCPU(), *duration)
+	for _, name := range powAlgorithmNames() {
+		cfg := GenesisConfig{PoW: name, PoWMemoryKiB: *memory}
+		hashrate := measureHashrate(cfg, *duration, benchShort)
+		fmt.Printf("%s%-8s:%s %14.0f H/s  %s\n", BoldCyan, name, Reset, hashrate, powAlgorithms[name])
+	}
+
+	// Miner memakai midstate: state SHA-256 setelah prefix header hanya dihitung sekali
+	fmt.Println(BoldYellow + "\nSHA-256 atas header blok:" + Reset)
+	header := measureHashrate(GenesisConfig{PoW: powSHA256}, *duration, benchHeader)
+	midstate := measureHashrate(GenesisConfig{PoW: powSHA256}, *duration, benchMidstate)
+	fmt.Printf("%s%-8s:%s %14.0f H/s  %s\n", BoldCyan, "utuh", Reset, header, "seluruh header di-hash setiap percobaan")
+	fmt.Printf("%s%-8s:%s %14.0f H/s  %s (%.1fx)\n", BoldCyan, "midstate", Reset, midstate, "hanya blok berisi nonce yang di-hash, seperti miner", midstate/header)
+	return nil
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Status pekerjaan mining
+const (
+	jobQueued    = "queued"
+	jobMining    = "mining"
+	jobDone      = "done"
+	jobFailed    = "failed"
+	jobCancelled = "cancelled"
+)
+
+// maxQueuedJobs bounds how many mining jobs may wait in the queue
+const maxQueuedJobs = 100
+
+// maxFinishedJobs is how many finished jobs are kept for GET /jobs; older ones are forgotten
+const maxFinishedJobs = 1000
+
+// miningJob is one block request processed by the mining queue
+type miningJob struct {
+	ID        int        `json:"id"`
+	Data      string     `json:"data"`
+	Status    string     `json:"status"`
+	Block     *Block     `json:"block,omitempty"`     // Blok hasil mining
+	Published []Block    `json:"published,omitempty"` // Blok yang ditambahkan ke blockchain oleh strategi
+	Error     string     `json:"error,omitempty"`
+	Submitted time.Time  `json:"submitted"`
+	Finished  *time.Time `json:"finished,omitempty"`
+	Callback  string     `json:"callback_url,omitempty"` // URL yang menerima POST status akhir pekerjaan
+	Warning   string     `json:"warning,omitempty"`      // Misalnya data yang mendekati batas ukuran chain
+
+	started time.Time // Saat mining dimulai, nol selama masih di antrian
+
+	observer MiningObserver
+	resume   *miningState // Status pencarian yang dilanjutkan, nil untuk mulai dari nonce 0
+	ctx      context.Context
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// miningQueue mines block requests one at a time so the menu and the API can both
+// request blocks while another one is being mined
+type miningQueue struct {
+	mu       sync.Mutex
+	chain    *Chain
+	strategy MinerStrategy
+	hasher   *externalHasher // Jika tidak nil, nonce dicari oleh proses hasher eksternal
+	notifier *miningNotifier // Jika tidak nil, pengguna diberi tahu saat mining yang lama selesai
+	extra    string          // Extra data miner yang disertakan di setiap blok
+	exporter *otlpExporter   // Jika tidak nil, setiap blok dikirim sebagai jejak OpenTelemetry
+
+	validatorKeys map[string]ed25519.PrivateKey // Key validator lokal yang memfinalisasi blok
+	jobs          map[int]*miningJob
+	finished      []int // ID pekerjaan yang sudah selesai, urut waktu selesai, untuk retensi
+	nextID        int
+	pending       chan *miningJob
+}
+
+// newMiningQueue creates a queue that mines onto chain using strategy
+func newMiningQueue(chain *Chain, strategy MinerStrategy) *miningQueue {
+	return &miningQueue{
+		chain:    chain,
+		strategy: strategy,
+		jobs:     make(map[int]*miningJob),
+		nextID:   1,
+		pending:  make(chan *miningJob, maxQueuedJobs),
+	}
+}
+
+// run processes queued jobs until ctx is cancelled
+func (q *miningQueue) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-q.pending:
+			q.process(job)
+		}
+	}
+}
+
+// Submit queues a block request; observer receives the miner telemetry of this job and
+// callback, if not empty, receives the finished job as a POST request
+func (q *miningQueue) Submit(data string, observer MiningObserver, callback string) (miningJob, error) {
+	return q.submit(data, observer, callback, nil)
+}
+
+// Resume queues a job that continues an interrupted search from the nonces in state
+func (q *miningQueue) Resume(state *miningState, observer MiningObserver) (miningJob, error) {
+	return q.submit(state.Data, observer, "", state)
+}
+
+func (q *miningQueue) submit(data string, observer MiningObserver, callback string, resume *miningState) (miningJob, error) {
+	if err := q.chain.Config().checkDataSize(data); err != nil {
+		return miningJob{}, err
+	}
+	if err := checkPayload(q.chain.Store(), data); err != nil {
+		return miningJob{}, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &miningJob{
+		ID:        q.nextID,
+		Data:      data,
+		Status:    jobQueued,
+		Submitted: time.Now(),
+		Callback:  callback,
+		Warning:   q.chain.Config().dataSizeWarning(len(data)),
+		observer:  observer,
+		resume:    resume,
+		ctx:       ctx,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+
+	select {
+	case q.pending <- job:
+	default:
+		cancel()
+		return miningJob{}, fmt.Errorf("antrian mining penuh (%d pekerjaan)", maxQueuedJobs)
+	}
+	q.jobs[job.ID] = job
+	q.nextID++
+	return *job, nil
+}
+
+// Job returns a snapshot of the job with the given ID
+func (q *miningQueue) Job(id int) (miningJob, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[id]
+	if !ok {
+		return miningJob{}, false
+	}
+	return *job, true
+}
+
+// Jobs returns a snapshot of every job ordered by ID
+func (q *miningQueue) Jobs() []miningJob {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	jobs := make([]miningJob, 0, len(q.jobs))
+	for _, job := range q.jobs {
+		jobs = append(jobs, *job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+	return jobs
+}
+
+// Wait blocks until the job finishes or ctx is cancelled and returns its final snapshot
+func (q *miningQueue) Wait(ctx context.Context, id int) (miningJob, error) {
+	q.mu.Lock()
+	job, ok := q.jobs[id]
+	q.mu.Unlock()
+	if !ok {
+		return miningJob{}, fmt.Errorf("pekerjaan mining %d tidak ditemukan", id)
+	}
+
+	select {
+	case <-job.done:
+	case <-ctx.Done():
+		return miningJob{}, ctx.Err()
+	}
+	// Pekerjaan mungkin sudah dilupakan oleh retensi, jadi snapshot diambil dari pointer-nya
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return *job, nil
+}
+
+// Cancel stops a queued or running job
+func (q *miningQueue) Cancel(id int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[id]
+	if !ok {
+		return false
+	}
+	job.cancel()
+	return true
+}
+
+// process mines one job and publishes the result according to the miner strategy
+func (q *miningQueue) process(job *miningJob) {
+	defer close(job.done)
+	defer job.cancel()
+
+	if job.ctx.Err() != nil {
+		q.finish(job, jobCancelled, nil, nil, nil)
+		return
+	}
+	q.setStatus(job, jobMining)
+
+	// Jejak OpenTelemetry mengikuti blok dari template sampai broadcast
+	trace := newBlockTrace(q.exporter, "block", "job.id", strconv.Itoa(job.ID), "miner.strategy", q.strategy.Name())
+	defer func() {
+		snapshot, _ := q.Job(job.ID)
+		trace.finish("job.status", snapshot.Status)
+	}()
+	endTemplate := trace.start("template")
+
+	// Strategi miner menentukan parent; penyimpanan belum mendukung fork
+	previousBlock := q.strategy.Parent(q.chain.Blocks())
+	if previousBlock.Index < q.chain.Tip().Index {
+		err := fmt.Errorf("strategi %s memilih blok %d sebagai parent, tetapi fork belum didukung", q.strategy.Name(), previousBlock.Index)
+		endTemplate("error", err.Error())
+		q.finish(job, jobFailed, nil, nil, err)
+		return
+	}
+
+	if err := waitForMedianTimePast(job.ctx, q.chain.Config(), q.chain.Blocks()); err != nil {
+		endTemplate("error", err.Error())
+		q.finish(job, failureStatus(err), nil, nil, err)
+		return
+	}
+
+	// Pencarian yang dilanjutkan hanya berlaku jika tip dan difficulty belum berubah
+	data := q.strategy.BlockData(job.Data)
+	difficulty := q.chain.Difficulty()
+	resume := job.resume
+	if resume != nil && resume.matches(previousBlock, difficulty) {
+		data = resume.Data
+	} else {
+		resume = nil
+	}
+	endTemplate("block.parent", previousBlock.Hash, "block.difficulty", strconv.Itoa(difficulty))
+
+	// Status pencarian disimpan berkala dan saat dibatalkan agar dapat dilanjutkan nanti
+	checkpoint := func(state *miningState) {
+		if err := saveMiningState(q.chain.Store(), state); err != nil {
+			fmt.Printf(Yellow+"Status mining tidak dapat disimpan: %v\n"+Reset, err)
+		}
+	}
+	// Evidence slashing yang tertunda ikut di-hash, jadi harus ditetapkan sebelum mining
+	evidence := q.chain.PendingEvidence()
+	var block Block
+	var err error
+	endMine, endTraceMine := startSpan("mine"), trace.start("mine")
+	if q.hasher != nil {
+		block, err = q.hasher.Mine(job.ctx, q.chain.Config(), data, q.extra, evidence, previousBlock, difficulty, job.observer)
+	} else {
+		block, err = mineBlockResumable(job.ctx, q.chain.Config(), data, q.extra, evidence, previousBlock, difficulty, job.observer, resume, checkpoint)
+	}
+	endMine()
+	if err != nil {
+		endTraceMine("error", err.Error())
+		q.finish(job, failureStatus(err), nil, nil, err)
+		return
+	}
+	endTraceMine("block.index", strconv.Itoa(block.Index), "block.hash", block.Hash, "block.nonce", strconv.FormatUint(block.Nonce, 10))
+	clearMiningState(q.chain.Store())
+
+	// Validator lokal memfinalisasi blok
+	finalizeBlock(q.chain.Config(), q.validatorKeys, &block)
+
+	// Blok yang dipublikasikan strategi disimpan dan ditambahkan ke blockchain
+	var published []Block
+	for _, candidate := range q.strategy.Publish(block) {
+		if err := q.chain.appendTraced(candidate, trace); err != nil {
+			q.finish(job, jobFailed, &block, published, err)
+			return
+		}
+		published = append(published, candidate)
+	}
+	q.finish(job, jobDone, &block, published, nil)
+}
+
+// failureStatus is the final status of a job that stopped with err: only a cancelled job is
+// jobCancelled, so errors from the hasher or the miner are reported instead of dropped
+func failureStatus(err error) string {
+	if errors.Is(err, context.Canceled) {
+		return jobCancelled
+	}
+	return jobFailed
+}
+
+func (q *miningQueue) setStatus(job *miningJob, status string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job.Status = status
+	if status == jobMining {
+		job.started = time.Now()
+	}
+	snapshot := *job
+	q.chain.events.Publish(chainEvent{Type: eventJob, Job: &snapshot})
+}
+
+func (q *miningQueue) finish(job *miningJob, status string, block *Block, published []Block, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	job.Status = status
+	job.Block = block
+	job.Published = published
+	job.Finished = &now
+	if err != nil {
+		job.Error = err.Error()
+	}
+	q.finished = append(q.finished, job.ID)
+	for len(q.finished) > maxFinishedJobs {
+		delete(q.jobs, q.finished[0])
+		q.finished = q.finished[1:]
+	}
+	snapshot := *job
+	q.chain.events.Publish(chainEvent{Type: eventJob, Job: &snapshot})
+	if job.Callback != "" {
+		go notifyJobCallback(snapshot)
+	}
+	if !job.started.IsZero() {
+		go q.notifier.jobFinished(snapshot, now.Sub(job.started))
+	}
+}
+
+// notifyJobCallback POSTs the finished job to its callback URL so clients don't have to poll
+func notifyJobCallback(job miningJob) {
+	body, err := json.Marshal(job)
+	if err != nil {
+		return
+	}
+	deliverWebhook(context.Background(), job.Callback, eventJob, body)
+}
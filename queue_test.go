@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// testQueue returns a mining queue on an in-memory copy of the fixture chain; at a high
+// difficulty a job keeps mining until it is cancelled
+func testQueue(t *testing.T, difficulty int) *miningQueue {
+	t.Helper()
+	cfg := fixtureGenesisConfig()
+	chain := newChain(cfg, newMemoryBlockStore(), fixtureChain(cfg, 1, 2, 1), difficulty)
+	return newMiningQueue(chain, honestStrategy{})
+}
+
+// waitJob waits at most a few seconds for job id to finish
+func waitJob(t *testing.T, q *miningQueue, id int) miningJob {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	job, err := q.Wait(ctx, id)
+	if err != nil {
+		t.Fatalf("job %d: %v", id, err)
+	}
+	return job
+}
+
+func TestMiningQueueLimit(t *testing.T) {
+	q := testQueue(t, 1)
+	for i := 0; i < maxQueuedJobs; i++ {
+		if _, err := q.Submit("x", multiMiningObserver{}, ""); err != nil {
+			t.Fatalf("job %d rejected: %v", i+1, err)
+		}
+	}
+	if _, err := q.Submit("x", multiMiningObserver{}, ""); err == nil {
+		t.Fatalf("job accepted beyond maxQueuedJobs")
+	}
+	if got := len(q.Jobs()); got != maxQueuedJobs {
+		t.Errorf("queue lists %d jobs, want %d", got, maxQueuedJobs)
+	}
+}
+
+func TestMiningQueueCancel(t *testing.T) {
+	q := testQueue(t, 12)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	running, _ := q.Submit("lama", multiMiningObserver{}, "")
+	queued, _ := q.Submit("antri", multiMiningObserver{}, "")
+	if !q.Cancel(queued.ID) {
+		t.Fatalf("Cancel(%d) = false for a queued job", queued.ID)
+	}
+	go q.run(ctx)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for job, _ := q.Job(running.ID); job.Status != jobMining; job, _ = q.Job(running.ID) {
+		if time.Now().After(deadline) {
+			t.Fatalf("job %d never started mining (status %s)", running.ID, job.Status)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if !q.Cancel(running.ID) {
+		t.Fatalf("Cancel(%d) = false for a running job", running.ID)
+	}
+
+	for _, id := range []int{running.ID, queued.ID} {
+		if job := waitJob(t, q, id); job.Status != jobCancelled || job.Block != nil || job.Finished == nil {
+			t.Errorf("job %d ended as %s with block %v, want %s without block", id, job.Status, job.Block, jobCancelled)
+		}
+	}
+	if q.Cancel(99) {
+		t.Errorf("Cancel succeeded for an unknown job")
+	}
+	if got := q.chain.Len(); got != 2 {
+		t.Errorf("chain has %d blocks after cancelling every job, want 2", got)
+	}
+}
+
+// TestMiningQueueRetention checks that only the last maxFinishedJobs finished jobs are kept
+func TestMiningQueueRetention(t *testing.T) {
+	q := testQueue(t, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.run(ctx)
+
+	const total = maxFinishedJobs + 5
+	for i := 0; i < total; i++ {
+		job, err := q.Submit("x", multiMiningObserver{}, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		q.Cancel(job.ID) // Selesai tanpa mining
+		waitJob(t, q, job.ID)
+	}
+
+	jobs := q.Jobs()
+	if len(jobs) != maxFinishedJobs {
+		t.Fatalf("queue keeps %d jobs, want %d", len(jobs), maxFinishedJobs)
+	}
+	if jobs[0].ID != total-maxFinishedJobs+1 || jobs[len(jobs)-1].ID != total {
+		t.Errorf("queue keeps jobs %d..%d, want %d..%d", jobs[0].ID, jobs[len(jobs)-1].ID, total-maxFinishedJobs+1, total)
+	}
+	if _, ok := q.Job(1); ok {
+		t.Errorf("oldest finished job is still listed")
+	}
+}
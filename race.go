@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// racePlayer is one competitor of the mining race
+type racePlayer struct {
+	Name     string
+	Blocks   atomic.Int64
+	Stale    atomic.Int64 // Blok yang ditemukan setelah pemain lain lebih dulu memperpanjang tip
+	Attempts atomic.Uint64
+}
+
+// raceBoard is a snapshot row of the leaderboard
+type raceBoard struct {
+	Name     string
+	Blocks   int64
+	Stale    int64
+	Work     float64 // Perkiraan jumlah hash yang diwakili blok pemain
+	Hashrate float64
+}
+
+// mineRace lets player mine on the tip of chain until ctx ends; a search is abandoned as soon
+// as another player extends the tip
+func mineRace(ctx context.Context, chain *Chain, player *racePlayer, difficulty int, seed int64) {
+	rng := rand.New(rand.NewSource(seed))
+	hasher := newMidstateHasher(chain.Config())
+	data := "race: " + player.Name
+	for ctx.Err() == nil {
+		tip := chain.Tip()
+		timestamp := time.Now().Format(time.RFC3339)
+		header := strconv.Itoa(tip.Index+1) + timestamp + data
+		// Nonce awal acak agar pemain tidak mencoba nonce yang sama
+		nonce := rng.Uint64() >> 1
+
+		for i := 1; ; i++ {
+			sum := hasher.hash(header, nonce, tip.Hash)
+			if meetsDifficulty(sum, difficulty) {
+				player.Attempts.Add(uint64(i % 4096))
+				block := Block{Index: tip.Index + 1, Timestamp: timestamp, Data: data, Nonce: nonce, Hash: hex.EncodeToString(sum[:]), PreviousHash: tip.Hash, Difficulty: difficulty}
+				if chain.Append(block) == nil {
+					player.Blocks.Add(1)
+				} else {
+					player.Stale.Add(1)
+				}
+				break
+			}
+			nonce++
+			if i%4096 == 0 {
+				player.Attempts.Add(4096)
+				if ctx.Err() != nil || chain.Tip().Hash != tip.Hash {
+					break
+				}
+			}
+		}
+	}
+}
+
+// raceLeaderboard ranks the players by blocks found, then by stale blocks as a tie breaker
+func raceLeaderboard(players []*racePlayer, difficulty int, elapsed time.Duration) []raceBoard {
+	board := make([]raceBoard, 0, len(players))
+	for _, player := range players {
+		blocks := player.Blocks.Load()
+		board = append(board, raceBoard{
+			Name:     player.Name,
+			Blocks:   blocks,
+			Stale:    player.Stale.Load(),
+			Work:     float64(blocks) * expectedHashes(difficulty),
+			Hashrate: float64(player.Attempts.Load()) / elapsed.Seconds(),
+		})
+	}
+	sort.SliceStable(board, func(i, j int) bool {
+		if board[i].Blocks != board[j].Blocks {
+			return board[i].Blocks > board[j].Blocks
+		}
+		return board[i].Stale < board[j].Stale
+	})
+	return board
+}
+
+// printRaceLeaderboard redraws the leaderboard in place
+func printRaceLeaderboard(board []raceBoard, height int, remaining time.Duration, clear bool) {
+	if clear {
+		fmt.Print("\033[H\033[2J")
+	}
+	fmt.Printf(BoldYellow+"=== Balapan Mining === tinggi chain %d, sisa %s\n"+Reset, height, remaining.Round(time.Second))
+	fmt.Printf("%s%-3s %-12s %6s %6s %14s %12s%s\n", BoldCyan, "#", "Pemain", "Blok", "Basi", "Work (hash)", "H/s", Reset)
+	for i, row := range board {
+		color := ""
+		if i == 0 && row.Blocks > 0 {
+			color = BoldGreen
+		}
+		fmt.Printf("%s%-3d %-12s %6d %6d %14.0f %12.0f%s\n", color, i+1, row.Name, row.Blocks, row.Stale, row.Work, row.Hashrate, Reset)
+	}
+}
+
+// runRaceCommand implements "race [-players a,b,c] [-duration 30s] [-difficulty D]": local players
+// race to extend one shared in-memory chain, with a live leaderboard
+func runRaceCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("race", flag.ContinueOnError)
+	names := fs.String("players", "alice,bob,carol", "Nama pemain, dipisah koma")
+	duration := fs.Duration("duration", 30*time.Second, "Lama balapan")
+	difficulty := fs.Int("difficulty", 4, "Tingkat kesulitan setiap blok")
+	live := fs.Bool("live", true, "Gambar ulang papan skor setiap detik")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "Seed untuk nonce awal pemain")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *difficulty < 1 || *difficulty > 8 {
+		return fmt.Errorf("difficulty balapan harus antara 1 dan 8")
+	}
+
+	var players []*racePlayer
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(*names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			return fmt.Errorf("nama pemain harus unik dan tidak kosong")
+		}
+		seen[name] = true
+		players = append(players, &racePlayer{Name: name})
+	}
+
+	// Balapan memakai chain baru di memori dengan difficulty tetap, tanpa menyentuh chain lokal
+	cfg, memory := defaultGenesisConfig, newMemoryBlockStore()
+	genesis := mineFixtureBlock(cfg, 0, time.Now(), "Genesis Block", genesisPreviousHash, *difficulty)
+	if err := saveBlock(memory, genesis); err != nil {
+		return err
+	}
+	chain := newChain(cfg, memory, []Block{genesis}, *difficulty)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *duration)
+	defer cancel()
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i, player := range players {
+		wg.Add(1)
+		go func(player *racePlayer, seed int64) {
+			defer wg.Done()
+			mineRace(ctx, chain, player, *difficulty, seed)
+		}(player, *seed+int64(i))
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for ctx.Err() == nil {
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+			if *live {
+				elapsed := time.Since(start)
+				printRaceLeaderboard(raceLeaderboard(players, *difficulty, elapsed), chain.Len()-1, *duration-elapsed, true)
+			}
+		}
+	}
+	wg.Wait()
+
+	board := raceLeaderboard(players, *difficulty, time.Since(start))
+	printRaceLeaderboard(board, chain.Len()-1, 0, *live)
+	if board[0].Blocks > 0 && (len(board) == 1 || board[0].Blocks > board[1].Blocks) {
+		fmt.Printf(BoldGreen+"\n%s menang dengan %d blok!\n"+Reset, board[0].Name, board[0].Blocks)
+	} else {
+		fmt.Println(BoldYellow + "\nSeri!" + Reset)
+	}
+	if !isBlockchainValid(cfg, chain.Blocks()) {
+		return fmt.Errorf("chain balapan tidak valid")
+	}
+	return nil
+}
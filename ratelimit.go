@@ -0,0 +1,95 @@
+package main
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxTrackedClients bounds the number of per-IP buckets kept in memory
+const maxTrackedClients = 10000
+
+// tokenBucket tracks the request allowance of one client
+type tokenBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// rateLimiter applies a per-IP token bucket to API requests
+type rateLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // Token yang ditambahkan per detik
+	burst   float64 // Kapasitas maksimum bucket
+	clients map[string]*tokenBucket
+}
+
+// newRateLimiter allows rate requests per second per IP with bursts up to burst requests
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		clients: make(map[string]*tokenBucket),
+	}
+}
+
+// allow reports whether the client identified by ip may make another request now
+func (l *rateLimiter) allow(ip string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	bucket, ok := l.clients[ip]
+	if !ok {
+		if len(l.clients) >= maxTrackedClients {
+			l.prune(now)
+		}
+		bucket = &tokenBucket{tokens: l.burst, lastSeen: now}
+		l.clients[ip] = bucket
+	}
+
+	// Isi ulang token sesuai waktu sejak request terakhir
+	bucket.tokens += now.Sub(bucket.lastSeen).Seconds() * l.rate
+	if bucket.tokens > l.burst {
+		bucket.tokens = l.burst
+	}
+	bucket.lastSeen = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// prune drops buckets that have refilled completely; the caller must hold l.mu
+func (l *rateLimiter) prune(now time.Time) {
+	for ip, bucket := range l.clients {
+		if bucket.tokens+now.Sub(bucket.lastSeen).Seconds()*l.rate >= l.burst {
+			delete(l.clients, ip)
+		}
+	}
+}
+
+// middleware rejects requests with 429 Too Many Requests once a client exceeds its allowance
+func (l *rateLimiter) middleware(next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+		if !l.allow(ip) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(1/l.rate)+1))
+			writeError(w, http.StatusTooManyRequests, "terlalu banyak request, coba lagi nanti")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// remoteClient talks to another node's REST API for thin client mode
+type remoteClient struct {
+	baseURL string
+	apiKey  string // Secret untuk header Authorization, boleh kosong
+	http    *http.Client
+}
+
+// newRemoteClient creates a client for the node at baseURL, e.g. http://server:8080
+func newRemoteClient(baseURL, apiKey string) *remoteClient {
+	return &remoteClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		// Mining di node remote bisa lama, jadi timeout dibuat longgar
+		http: &http.Client{Timeout: 30 * time.Minute},
+	}
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response into out
+func (c *remoteClient) do(method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.Error != "" {
+			return fmt.Errorf("%s (HTTP %d)", apiErr.Error, resp.StatusCode)
+		}
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// Blocks downloads the whole chain page by page
+func (c *remoteClient) Blocks() ([]Block, error) {
+	defer startSpan("sync.download")()
+	var blocks []Block
+	for {
+		var page []Block
+		path := "/blocks?limit=" + strconv.Itoa(maxBlocksPageSize) + "&cursor=" + strconv.Itoa(len(blocks))
+		if err := c.do(http.MethodGet, path, nil, &page); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, page...)
+		if len(page) < maxBlocksPageSize {
+			return blocks, nil
+		}
+	}
+}
+
+func (c *remoteClient) Difficulty() (int, error) {
+	var response struct {
+		Difficulty int `json:"difficulty"`
+	}
+	err := c.do(http.MethodGet, "/difficulty", nil, &response)
+	return response.Difficulty, err
+}
+
+func (c *remoteClient) SetDifficulty(difficulty int) error {
+	return c.do(http.MethodPut, "/difficulty", map[string]int{"difficulty": difficulty}, nil)
+}
+
+func (c *remoteClient) MineBlock(data string) (Block, error) {
+	var block Block
+	err := c.do(http.MethodPost, "/blocks", map[string]string{"data": data}, &block)
+	return block, err
+}
+
+// Validate reports whether the remote chain is valid and, if not, the failing rule and its message
+func (c *remoteClient) Validate() (bool, string, string, error) {
+	var response struct {
+		Valid bool   `json:"valid"`
+		Rule  string `json:"rule"`
+		Error string `json:"error"`
+	}
+	err := c.do(http.MethodGet, "/validate", nil, &response)
+	return response.Valid, response.Rule, response.Error, err
+}
+
+// runThinClient runs the interactive menu against a remote node instead of local storage
+func runThinClient(client *remoteClient, reader *bufio.Reader) {
+	blocks, err := client.Blocks()
+	if err != nil {
+		fmt.Println(Red+"Error menghubungi node remote:"+Reset, err)
+		return
+	}
+	fmt.Printf(Green+"Terhubung ke %s dengan %d blok.\n"+Reset, client.baseURL, len(blocks))
+
+	// Genesis config node remote tidak diambil, jadi ukuran blok dibandingkan dengan default
+	cfg := defaultGenesisConfig
+
+	for {
+		menuDisplay()
+		option, _ := reader.ReadString('\n')
+		option = strings.TrimSpace(option)
+
+		switch option {
+		case "1":
+			fmt.Print(BoldCyan + "Masukkan data (teks) yang akan di-mining: " + Reset)
+			data, _ := reader.ReadString('\n')
+			data = strings.TrimSpace(data)
+
+			fmt.Println(BoldYellow + "\nMeminta node remote melakukan mining..." + Reset)
+			startTime := time.Now()
+			block, err := client.MineBlock(data)
+			if err != nil {
+				fmt.Println(Red+"Error mining di node remote:"+Reset, err)
+				continue
+			}
+			fmt.Println(Green + "Blok baru berhasil ditambahkan:" + Reset)
+			fmt.Printf("%sIndex         :%s %d\n", BoldCyan, Reset, block.Index)
+			fmt.Printf("%sNonce         :%s %d\n", BoldCyan, Reset, block.Nonce)
+			fmt.Printf("%sHash          :%s %s\n", BoldCyan, Reset, block.Hash)
+			fmt.Printf("%sPreviousHash  :%s %s\n", BoldCyan, Reset, block.PreviousHash)
+			fmt.Printf("%sDifficulty    :%s %d\n", BoldCyan, Reset, block.Difficulty)
+			printBlockSize(cfg, block)
+			fmt.Printf("%sWaktu         :%s %s\n", BoldCyan, Reset, time.Since(startTime))
+
+		case "2":
+			blocks, err := client.Blocks()
+			if err != nil {
+				fmt.Println(Red+"Error:"+Reset, err)
+			} else if len(blocks) == 0 {
+				fmt.Println(Yellow + "Blockchain masih kosong." + Reset)
+			} else {
+				displayBlockchain(cfg, blocks)
+			}
+
+		case "3":
+			if current, err := client.Difficulty(); err == nil {
+				fmt.Printf(BoldYellow+"Tingkat kesulitan node remote saat ini: %d\n"+Reset, current)
+			}
+			fmt.Print(BoldCyan + "Masukkan tingkat kesulitan baru (jumlah nol di awal hash): " + Reset)
+			difficultyInput, _ := reader.ReadString('\n')
+			newDifficulty, err := strconv.Atoi(strings.TrimSpace(difficultyInput))
+			if err != nil || newDifficulty < 0 || newDifficulty > 64 {
+				fmt.Println(Red + "Tingkat kesulitan harus berupa angka antara 0 dan 64." + Reset)
+				continue
+			}
+			if err := client.SetDifficulty(newDifficulty); err != nil {
+				fmt.Println(Red+"Error:"+Reset, err)
+				continue
+			}
+			fmt.Printf(Green+"Tingkat kesulitan berhasil diubah menjadi %d.\n"+Reset, newDifficulty)
+
+		case "4":
+			fmt.Println(BoldYellow + "Memvalidasi blockchain di node remote..." + Reset)
+			valid, rule, message, err := client.Validate()
+			if err != nil {
+				fmt.Println(Red+"Error:"+Reset, err)
+			} else if valid {
+				fmt.Println(Green + "Blockchain is valid." + Reset)
+			} else {
+				fmt.Printf(Red+"[%s] %s\n"+Reset, rule, message)
+			}
+
+		case "5":
+			fmt.Println(Yellow + "Keluar dari program." + Reset)
+			return
+
+		default:
+			fmt.Println(Red + "Opsi tidak valid. Silakan pilih opsi yang tersedia." + Reset)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// retargetSample is one past block as seen by a difficulty-adjustment algorithm
+type retargetSample struct {
+	Height int     // Index blok di chain
+	Work   float64 // Jumlah hash yang diharapkan untuk menemukan blok
+	Time   float64 // Timestamp blok dalam detik
+}
+
+// Retargeter computes the work required for the next block from recent history
+type Retargeter interface {
+	Name() string
+	// NextWork returns the expected hashes for the next block; history is ordered
+	// oldest first, always contains at least one block and may start after genesis
+	NextWork(history []retargetSample, targetInterval float64) float64
+}
+
+// bitcoinRetarget adjusts once every Window blocks by the ratio of expected to actual timespan, clamped to 4x
+type bitcoinRetarget struct {
+	Window int
+}
+
+func (r bitcoinRetarget) Name() string { return "bitcoin" }
+
+func (r bitcoinRetarget) NextWork(history []retargetSample, targetInterval float64) float64 {
+	last := history[len(history)-1]
+	// Hanya menyesuaikan di batas periode retarget; history dibatasi, jadi batasnya diambil
+	// dari tinggi blok, bukan dari panjang history
+	if last.Height < r.Window || last.Height%r.Window != 0 || len(history) <= r.Window {
+		return last.Work
+	}
+
+	actual := last.Time - history[len(history)-1-r.Window].Time
+	expected := targetInterval * float64(r.Window)
+	if actual < expected/4 {
+		actual = expected / 4
+	}
+	if actual > expected*4 {
+		actual = expected * 4
+	}
+	return last.Work * expected / actual
+}
+
+// emaRetarget sets the work after every block from exponential moving averages of block work
+// and solve time over the whole history. Averaging the accumulated history instead of nudging
+// the last block's work lets small deviations add up past the 16x steps of hex difficulty.
+type emaRetarget struct {
+	N float64 // Jumlah blok efektif pada rata-rata
+}
+
+func (r emaRetarget) Name() string { return "ema" }
+
+func (r emaRetarget) NextWork(history []retargetSample, targetInterval float64) float64 {
+	if len(history) < 2 {
+		return history[len(history)-1].Work
+	}
+
+	work := history[1].Work
+	solveTime := clampSolveTime(history[1].Time-history[0].Time, targetInterval)
+	for i := 2; i < len(history); i++ {
+		work += (history[i].Work - work) / r.N
+		solveTime += (clampSolveTime(history[i].Time-history[i-1].Time, targetInterval) - solveTime) / r.N
+	}
+	return work * targetInterval / solveTime
+}
+
+// lwmaRetarget uses a linearly weighted moving average of the last N solve times,
+// weighting recent blocks more heavily (the zawy12 LWMA)
+type lwmaRetarget struct {
+	N int
+}
+
+func (r lwmaRetarget) Name() string { return "lwma" }
+
+func (r lwmaRetarget) NextWork(history []retargetSample, targetInterval float64) float64 {
+	n := r.N
+	if len(history)-1 < n {
+		n = len(history) - 1
+	}
+	if n < 1 {
+		return history[len(history)-1].Work
+	}
+
+	var weightedTime, totalWork float64
+	start := len(history) - n
+	for i := start; i < len(history); i++ {
+		weight := float64(i - start + 1)
+		weightedTime += weight * clampSolveTime(history[i].Time-history[i-1].Time, targetInterval)
+		totalWork += history[i].Work
+	}
+	averageWork := totalWork / float64(n)
+	weights := float64(n*(n+1)) / 2
+	return averageWork * targetInterval * weights / weightedTime
+}
+
+// fixedRetarget never changes the work
+type fixedRetarget struct{}
+
+func (fixedRetarget) Name() string { return "fixed" }
+
+func (fixedRetarget) NextWork(history []retargetSample, _ float64) float64 {
+	return history[len(history)-1].Work
+}
+
+// clampSolveTime limits a single solve time so timestamp noise cannot swing the difficulty too far
+func clampSolveTime(solveTime, targetInterval float64) float64 {
+	if solveTime < 1 {
+		return 1
+	}
+	if solveTime > 6*targetInterval {
+		return 6 * targetInterval
+	}
+	return solveTime
+}
+
+// retargeters lists the available difficulty-adjustment algorithms by name
+var retargeters = map[string]Retargeter{
+	"fixed":   fixedRetarget{},
+	"bitcoin": bitcoinRetarget{Window: 2016},
+	"ema":     emaRetarget{N: 20},
+	"lwma":    lwmaRetarget{N: 45},
+}
+
+// newRetargeter returns the algorithm registered under name
+func newRetargeter(name string) (Retargeter, error) {
+	retargeter, ok := retargeters[name]
+	if !ok {
+		return nil, fmt.Errorf("algoritma retarget tidak dikenal: %q (tersedia: %v)", name, retargeterNames())
+	}
+	return retargeter, nil
+}
+
+// retargeterNames returns the registered algorithm names in sorted order
+func retargeterNames() []string {
+	names := make([]string, 0, len(retargeters))
+	for name := range retargeters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
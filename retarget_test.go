@@ -0,0 +1,95 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// retargetChain returns count blocks at difficulty whose timestamps are solveTime apart
+func retargetChain(count, difficulty int, solveTime time.Duration) []Block {
+	blockchain := make([]Block, count)
+	for i := range blockchain {
+		blockchain[i] = Block{
+			Index:      i,
+			Timestamp:  fixtureEpoch.Add(time.Duration(i) * solveTime).Format(time.RFC3339),
+			Difficulty: difficulty,
+		}
+	}
+	return blockchain
+}
+
+func TestNextDifficulty(t *testing.T) {
+	const target = 60 // Detik per blok
+
+	tests := []struct {
+		name      string
+		retarget  string
+		blocks    int
+		solveTime time.Duration
+		want      int
+		wantOK    bool
+	}{
+		{name: "manual", retarget: retargetManual, blocks: 50, solveTime: 4 * time.Second, wantOK: false},
+		{name: "fixed ignores fast blocks", retarget: "fixed", blocks: 50, solveTime: 4 * time.Second, want: 3, wantOK: true},
+
+		// EMA dan LWMA: blok 15x terlalu cepat menaikkan satu digit hex, blok 5x terlalu lambat menurunkannya
+		{name: "ema on target", retarget: "ema", blocks: 50, solveTime: target * time.Second, want: 3, wantOK: true},
+		{name: "ema fast blocks", retarget: "ema", blocks: 50, solveTime: 4 * time.Second, want: 4, wantOK: true},
+		{name: "ema slow blocks", retarget: "ema", blocks: 50, solveTime: 300 * time.Second, want: 2, wantOK: true},
+		{name: "ema single block", retarget: "ema", blocks: 1, solveTime: 4 * time.Second, want: 3, wantOK: true},
+		{name: "lwma on target", retarget: "lwma", blocks: 50, solveTime: target * time.Second, want: 3, wantOK: true},
+		{name: "lwma fast blocks", retarget: "lwma", blocks: 50, solveTime: 4 * time.Second, want: 4, wantOK: true},
+		{name: "lwma slow blocks", retarget: "lwma", blocks: 50, solveTime: 300 * time.Second, want: 2, wantOK: true},
+
+		// Bitcoin hanya menyesuaikan di tinggi kelipatan 2016, dengan batas 4x
+		{name: "bitcoin before first boundary", retarget: "bitcoin", blocks: 2016, solveTime: 15 * time.Second, want: 3, wantOK: true},
+		{name: "bitcoin at first boundary", retarget: "bitcoin", blocks: 2017, solveTime: 15 * time.Second, want: 4, wantOK: true},
+		{name: "bitcoin on target at boundary", retarget: "bitcoin", blocks: 2017, solveTime: target * time.Second, want: 3, wantOK: true},
+		{name: "bitcoin off boundary beyond history cap", retarget: "bitcoin", blocks: 3*2016 + 64, solveTime: 15 * time.Second, want: 3, wantOK: true},
+		{name: "bitcoin boundary beyond history cap", retarget: "bitcoin", blocks: 3*2016 + 1, solveTime: 15 * time.Second, want: 4, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaultGenesisConfig
+			cfg.Retarget = tt.retarget
+			cfg.TargetInterval = target
+
+			got, ok := nextDifficulty(cfg, retargetChain(tt.blocks, 3, tt.solveTime))
+			if ok != tt.wantOK {
+				t.Fatalf("nextDifficulty ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Errorf("nextDifficulty = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestEMARetargetSettles mines a chain against a fixed hashrate and checks that the EMA
+// reaches the difficulty matching the target interval and then stays there
+func TestEMARetargetSettles(t *testing.T) {
+	cfg := defaultGenesisConfig
+	cfg.Retarget = "ema"
+	cfg.TargetInterval = 60
+	hashrate := expectedHashes(4) / cfg.TargetInterval // Difficulty 4 tepat sesuai target
+
+	blockchain := []Block{{Index: 0, Timestamp: fixtureEpoch.Format(time.RFC3339), Difficulty: 2}}
+	now := fixtureEpoch
+	var changes int
+	for i := 1; i < 200; i++ {
+		difficulty, _ := nextDifficulty(cfg, blockchain)
+		if difficulty != blockchain[i-1].Difficulty {
+			changes++
+		}
+		now = now.Add(time.Duration(expectedHashes(difficulty) / hashrate * float64(time.Second)))
+		blockchain = append(blockchain, Block{Index: i, Timestamp: now.Format(time.RFC3339), Difficulty: difficulty})
+	}
+
+	if tip := blockchain[len(blockchain)-1].Difficulty; tip != 4 {
+		t.Errorf("difficulty after 200 blocks = %d, want 4", tip)
+	}
+	if changes > 2 {
+		t.Errorf("difficulty changed %d times, want it to settle", changes)
+	}
+}
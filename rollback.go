@@ -0,0 +1,116 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// archiveDir is the directory inside the chain directory that keeps blocks removed by rollback
+const archiveDir = "archive"
+
+// Rollback moves every block above height to archive/rollback-<time> and returns the removed
+// blocks; the archived files are byte-for-byte copies, so encrypted blocks stay encrypted
+func (s fileBlockStore) Rollback(height int) (string, []Block, error) {
+	blocks, err := s.LoadBlocks()
+	if err != nil {
+		return "", nil, err
+	}
+	if height < 0 || height >= len(blocks) {
+		return "", nil, fmt.Errorf("tinggi harus antara 0 dan %d", len(blocks)-1)
+	}
+	removed := blocks[height+1:]
+	if len(removed) == 0 {
+		return "", nil, nil
+	}
+
+	archive := filepath.Join(s.dir, archiveDir, "rollback-"+time.Now().Format("20060102-150405"))
+	if err := os.MkdirAll(archive, os.ModePerm); err != nil {
+		return "", nil, err
+	}
+	// Data blok yang dibuang disimpan terpisah agar mudah di-mine ulang
+	var data strings.Builder
+	for _, block := range removed {
+		name := fmt.Sprintf("block%d.json", block.Index)
+		if err := copyStoreFile(filepath.Join(s.dir, name), filepath.Join(archive, name)); err != nil {
+			return "", nil, err
+		}
+		data.WriteString(block.Data + "\n")
+	}
+	if err := os.WriteFile(filepath.Join(archive, "data.txt"), []byte(data.String()), 0644); err != nil {
+		return "", nil, err
+	}
+
+	// Hapus dari blok tertinggi agar chain tetap utuh jika terhenti di tengah
+	for i := len(removed) - 1; i >= 0; i-- {
+		if err := s.Remove(fmt.Sprintf("block%d.json", removed[i].Index)); err != nil {
+			return "", nil, err
+		}
+	}
+	// Status mining yang tersimpan merujuk ke tip lama
+	s.Remove(miningStateFile)
+	return archive, removed, nil
+}
+
+// runRollbackCommand implements "rollback [-yes] <height>"
+func runRollbackCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("rollback", flag.ContinueOnError)
+	yes := fs.Bool("yes", false, "Jangan meminta konfirmasi")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("penggunaan: rollback [-yes] <height>")
+	}
+	height, err := strconv.Atoi(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("height harus berupa angka")
+	}
+	files, ok := store.(fileBlockStore)
+	if !ok {
+		return fmt.Errorf("rollback hanya tersedia untuk chain di disk yang dapat ditulis")
+	}
+
+	blocks, err := files.LoadBlocks()
+	if err != nil {
+		return err
+	}
+	if height >= 0 && height < len(blocks)-1 && !*yes {
+		fmt.Printf(Yellow+"%d blok di atas tinggi %d akan dipindahkan ke arsip. Lanjutkan? (y/n): "+Reset, len(blocks)-1-height, height)
+		var answer string
+		fmt.Scanln(&answer)
+		if answer != "y" {
+			fmt.Println("Rollback dibatalkan.")
+			return nil
+		}
+	}
+
+	archive, removed, err := files.Rollback(height)
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		fmt.Printf(Yellow+"Tip sudah berada di tinggi %d, tidak ada yang di-rollback.\n"+Reset, height)
+		return nil
+	}
+	fmt.Printf(Green+"Blockchain di-rollback ke tinggi %d; %d blok diarsipkan di %s.\n"+Reset, height, len(removed), archive)
+	fmt.Println(Cyan + "Data blok yang dibuang (juga di data.txt, dapat di-mine ulang):" + Reset)
+	for _, block := range removed {
+		fmt.Printf("  %d: %s\n", block.Index, block.Data)
+	}
+
+	// Periksa ulang chain yang tersisa; status lain dibangun ulang dari blok saat node dimulai
+	cfg, blockchain, err := loadChain(store)
+	if err != nil {
+		return err
+	}
+	if _, rule, err := verifyChain(cfg, blockchain, verifyStandard); err != nil {
+		return fmt.Errorf("chain setelah rollback tidak valid (aturan %s): %v", rule, err)
+	}
+	fmt.Printf(Green+"Tip baru: blok %d (%s)\n"+Reset, blockchain[len(blockchain)-1].Index, blockchain[len(blockchain)-1].Hash)
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRollbackArchive(t *testing.T) {
+	cfg := fixtureGenesisConfig()
+	blockchain := fixtureChain(cfg, 1, 5, 1)
+	store := testStore(t, blockchain)
+	if err := store.WriteFile(miningStateFile, []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var originals [][]byte
+	for _, name := range []string{"block3.json", "block4.json"} {
+		data, err := store.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		originals = append(originals, data)
+	}
+
+	archive, removed, err := store.Rollback(2)
+	if err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if len(removed) != 2 || removed[0].Index != 3 || removed[1].Index != 4 {
+		t.Fatalf("Rollback removed %v, want blocks 3 and 4", removed)
+	}
+	if got, want := blockHashes(t, store), blockHashes(t, testStore(t, blockchain[:3])); !reflect.DeepEqual(got, want) {
+		t.Errorf("blocks after rollback = %v, want %v", got, want)
+	}
+	if _, err := store.ReadFile(miningStateFile); !os.IsNotExist(err) {
+		t.Errorf("%s pointing at the old tip survived the rollback", miningStateFile)
+	}
+
+	// Arsip berisi salinan byte demi byte dan data blok yang dibuang
+	if filepath.Dir(archive) != filepath.Join(store.dir, archiveDir) {
+		t.Errorf("archive %s is not inside %s", archive, archiveDir)
+	}
+	for i, name := range []string{"block3.json", "block4.json"} {
+		data, err := os.ReadFile(filepath.Join(archive, name))
+		if err != nil || !bytes.Equal(data, originals[i]) {
+			t.Errorf("archived %s differs from the removed file (err %v)", name, err)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(archive, "data.txt"))
+	if want := blockchain[3].Data + "\n" + blockchain[4].Data + "\n"; err != nil || string(data) != want {
+		t.Errorf("data.txt = %q, want %q (err %v)", data, want, err)
+	}
+
+	// Rollback ke tip tidak mengarsipkan apa pun
+	archive, removed, err = store.Rollback(2)
+	if err != nil || archive != "" || len(removed) != 0 {
+		t.Errorf("Rollback to the tip = %q, %v, %v; want nothing archived", archive, removed, err)
+	}
+	for _, height := range []int{-1, 3} {
+		if _, _, err := store.Rollback(height); err == nil {
+			t.Errorf("Rollback(%d) accepted a height outside the chain", height)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// genesisPreviousHash is the PreviousHash every genesis block must carry
+const genesisPreviousHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// maxExtraDataSize is the consensus limit on a block's extra data in bytes, as Ethereum's extraData
+const maxExtraDataSize = 32
+
+// defaultMedianTimeSpan is the number of blocks whose median timestamp a new block must exceed, as in Bitcoin
+const defaultMedianTimeSpan = 11
+
+// verifyLevel selects how thoroughly a chain is verified
+type verifyLevel int
+
+const (
+	verifyQuick    verifyLevel = iota // Header dan keterkaitan saja, tanpa hashing ulang
+	verifyStandard                    // Ditambah hashing ulang setiap blok dan retarget
+	verifyParanoid                    // Ditambah tanda tangan validator dan evidence slashing
+)
+
+// verifyLevelNames maps the names used by -verify and the validate command to levels
+var verifyLevelNames = map[string]verifyLevel{"quick": verifyQuick, "standard": verifyStandard, "paranoid": verifyParanoid}
+
+func (l verifyLevel) String() string {
+	for name, level := range verifyLevelNames {
+		if level == l {
+			return name
+		}
+	}
+	return "level(" + fmt.Sprint(int(l)) + ")"
+}
+
+// ValidationRule is a named check applied to every block of the blockchain
+type ValidationRule struct {
+	Name        string
+	Description string
+	// Level is the lowest verification level that runs the rule; custom rules default to quick
+	Level verifyLevel
+	// Check validates blockchain[i] of a chain with genesis config cfg; earlier blocks have already passed every rule
+	Check func(cfg GenesisConfig, blockchain []Block, i int) error
+}
+
+// validationRules is the ordered rule set used by isBlockchainValid
+var validationRules = []ValidationRule{
+	{Name: "hash", Description: "Hash blok sesuai dengan isi blok", Level: verifyStandard, Check: checkBlockHash},
+	{Name: "hash-link", Description: "PreviousHash menunjuk ke hash blok sebelumnya", Level: verifyQuick, Check: checkHashLink},
+	{Name: "difficulty", Description: "Hash memenuhi tingkat kesulitan blok", Level: verifyQuick, Check: checkDifficulty},
+	{Name: "retarget", Description: "Tingkat kesulitan sesuai algoritma retarget chain", Level: verifyStandard, Check: checkRetarget},
+	{Name: "size", Description: "Data blok tidak melebihi max_data_size chain", Level: verifyQuick, Check: checkBlockSize},
+	{Name: "extra-data", Description: "Extra data miner tidak melebihi 32 byte", Level: verifyQuick, Check: checkExtraData},
+	{Name: "timestamp", Description: "Timestamp valid, melewati median time past (atau tidak mundur), dan tidak terlalu jauh di masa depan", Level: verifyQuick, Check: checkTimestamp},
+	{Name: "delegation", Description: "Blok delegasi pada chain hybrid menunjuk validator yang ada dan belum di-slash", Level: verifyQuick, Check: checkDelegation},
+	{Name: "evidence", Description: "Bukti double signing valid dan tiap validator hanya di-slash sekali", Level: verifyParanoid, Check: checkEvidence},
+	{Name: "finality", Description: "Blok hybrid ditandatangani validator dengan lebih dari 2/3 stake yang belum di-slash", Level: verifyParanoid, Check: checkFinality},
+}
+
+// registerValidationRule appends an extra rule after the built-in ones
+func registerValidationRule(rule ValidationRule) error {
+	if rule.Name == "" || rule.Check == nil {
+		return fmt.Errorf("aturan validasi harus memiliki nama dan fungsi Check")
+	}
+	for _, existing := range validationRules {
+		if existing.Name == rule.Name {
+			return fmt.Errorf("aturan validasi %q sudah terdaftar", rule.Name)
+		}
+	}
+	validationRules = append(validationRules, rule)
+	return nil
+}
+
+// validateBlock runs every rule against blockchain[i] and returns the first violated one
+func validateBlock(cfg GenesisConfig, blockchain []Block, i int) (string, error) {
+	return validateBlockAt(cfg, blockchain, i, verifyParanoid)
+}
+
+// validateBlockAt runs the rules up to the given verification level against blockchain[i]
+func validateBlockAt(cfg GenesisConfig, blockchain []Block, i int, level verifyLevel) (string, error) {
+	for _, rule := range validationRules {
+		if rule.Level > level {
+			continue
+		}
+		if err := rule.Check(cfg, blockchain, i); err != nil {
+			return rule.Name, err
+		}
+	}
+	return "", nil
+}
+
+// checkBlockHash verifies the stored hash matches the block contents
+func checkBlockHash(cfg GenesisConfig, blockchain []Block, i int) error {
+	block := blockchain[i]
+	if block.Hash != calculateHash(cfg, block) {
+		return fmt.Errorf("Invalid hash at block %d", block.Index)
+	}
+	return nil
+}
+
+// checkHashLink verifies the block points to its predecessor (or to the zero hash for genesis)
+func checkHashLink(cfg GenesisConfig, blockchain []Block, i int) error {
+	block := blockchain[i]
+	if i == 0 {
+		if block.PreviousHash != genesisPreviousHash {
+			return fmt.Errorf("Invalid PreviousHash for Genesis Block")
+		}
+		return nil
+	}
+	if block.PreviousHash != blockchain[i-1].Hash {
+		return fmt.Errorf("Previous hash mismatch at block %d", block.Index)
+	}
+	return nil
+}
+
+// checkDifficulty verifies the hash has as many leading zeros as the block's difficulty
+func checkDifficulty(cfg GenesisConfig, blockchain []Block, i int) error {
+	block := blockchain[i]
+	prefix := strings.Repeat("0", block.Difficulty)
+	if !strings.HasPrefix(block.Hash, prefix) {
+		return fmt.Errorf("Block %d does not meet difficulty requirements", block.Index)
+	}
+	return nil
+}
+
+// checkRetarget verifies the block difficulty matches the chain's retarget algorithm, if any
+func checkRetarget(cfg GenesisConfig, blockchain []Block, i int) error {
+	if i == 0 {
+		return nil
+	}
+	expected, ok := nextDifficulty(cfg, blockchain[:i])
+	if ok && blockchain[i].Difficulty != expected {
+		return fmt.Errorf("Block %d has difficulty %d, %s retargeting requires %d", blockchain[i].Index, blockchain[i].Difficulty, cfg.Retarget, expected)
+	}
+	return nil
+}
+
+// checkBlockSize verifies the block data fits the size limit of the chain; the genesis block is exempt
+func checkBlockSize(cfg GenesisConfig, blockchain []Block, i int) error {
+	if i > 0 && cfg.MaxDataSize > 0 && len(blockchain[i].Data) > cfg.MaxDataSize {
+		return fmt.Errorf("Block %d has %d bytes of data, the chain allows %d", blockchain[i].Index, len(blockchain[i].Data), cfg.MaxDataSize)
+	}
+	return nil
+}
+
+// checkExtraData verifies the miner's extra data fits the consensus limit
+func checkExtraData(cfg GenesisConfig, blockchain []Block, i int) error {
+	if size := len(blockchain[i].ExtraData); size > maxExtraDataSize {
+		return fmt.Errorf("Block %d has %d bytes of extra data, the limit is %d", blockchain[i].Index, size, maxExtraDataSize)
+	}
+	return nil
+}
+
+// validExtraData rejects extra data a miner could not put in a block
+func validExtraData(extra string) error {
+	if len(extra) > maxExtraDataSize {
+		return fmt.Errorf("extra data %d byte melebihi batas %d byte", len(extra), maxExtraDataSize)
+	}
+	return nil
+}
+
+// checkTimestamp verifies the timestamp parses, does not go backwards, and is not far in the future
+func checkTimestamp(cfg GenesisConfig, blockchain []Block, i int) error {
+	block := blockchain[i]
+	timestamp, err := time.Parse(time.RFC3339, block.Timestamp)
+	if err != nil {
+		return fmt.Errorf("Invalid timestamp at block %d", block.Index)
+	}
+	if timestamp.After(time.Now().Add(cfg.maxFutureBlockTime())) {
+		return fmt.Errorf("Timestamp of block %d is too far in the future", block.Index)
+	}
+	if i > 0 && cfg.MedianTimeSpan > 0 {
+		if mtp := medianTimePast(blockchain[:i], cfg.MedianTimeSpan); !timestamp.After(mtp) {
+			return fmt.Errorf("Timestamp of block %d is not after the median time past %s", block.Index, mtp.Format(time.RFC3339))
+		}
+	} else if i > 0 {
+		previous, err := time.Parse(time.RFC3339, blockchain[i-1].Timestamp)
+		if err == nil && timestamp.Before(previous) {
+			return fmt.Errorf("Timestamp of block %d is earlier than its previous block", block.Index)
+		}
+	}
+	return nil
+}
+
+// medianTimePast returns the median timestamp of the last span blocks of blockchain; a single
+// miner with a wrong clock cannot move it, unlike the timestamp of the previous block
+func medianTimePast(blockchain []Block, span int) time.Time {
+	var times []time.Time
+	for i := max(len(blockchain)-span, 0); i < len(blockchain); i++ {
+		if timestamp, err := time.Parse(time.RFC3339, blockchain[i].Timestamp); err == nil {
+			times = append(times, timestamp)
+		}
+	}
+	if len(times) == 0 {
+		return time.Time{}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times[len(times)/2]
+}
+
+// waitForMedianTimePast blocks until the clock has passed the median time past of blockchain, so
+// a block mined now is accepted; it only waits when blocks are mined faster than one per second
+func waitForMedianTimePast(ctx context.Context, cfg GenesisConfig, blockchain []Block) error {
+	if cfg.MedianTimeSpan == 0 {
+		return nil
+	}
+	// Timestamp blok berpresisi detik, jadi blok baru baru valid pada detik setelah median
+	earliest := medianTimePast(blockchain, cfg.MedianTimeSpan).Add(time.Second)
+	if wait := time.Until(earliest); wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// printValidationRules prints the active rule set in order
+func printValidationRules() {
+	fmt.Println(BoldYellow + "\n=== Aturan Validasi ===" + Reset)
+	for i, rule := range validationRules {
+		fmt.Printf("%s%d. %-12s%s [%-8s] %s\n", BoldCyan, i+1, rule.Name, Reset, rule.Level, rule.Description)
+	}
+}
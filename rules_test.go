@@ -0,0 +1,199 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// remine searches a new nonce for block after its fields were changed, keeping its difficulty
+func remine(cfg GenesisConfig, block Block) Block {
+	prefix := strings.Repeat("0", block.Difficulty)
+	for block.Nonce = 0; ; block.Nonce++ {
+		block.Hash = calculateHash(cfg, block)
+		if strings.HasPrefix(block.Hash, prefix) {
+			return block
+		}
+	}
+}
+
+func TestVerifyChainRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     verifyLevel
+		config    func(cfg *GenesisConfig)
+		tamper    func(cfg GenesisConfig, bc []Block) []Block
+		wantRule  string // Kosong berarti chain harus valid
+		wantBlock string // Potongan pesan error yang menunjuk blok yang gagal
+	}{
+		{
+			name:  "valid chain",
+			level: verifyParanoid,
+		},
+		{
+			name:  "data changed without mining",
+			level: verifyStandard,
+			tamper: func(cfg GenesisConfig, bc []Block) []Block {
+				bc[3].Data += " (diubah)"
+				return bc
+			},
+			wantRule:  "hash",
+			wantBlock: "block 3",
+		},
+		{
+			name:  "quick level does not rehash",
+			level: verifyQuick,
+			tamper: func(cfg GenesisConfig, bc []Block) []Block {
+				bc[3].Data += " (diubah)"
+				return bc
+			},
+		},
+		{
+			name:  "genesis with wrong previous hash",
+			level: verifyParanoid,
+			tamper: func(cfg GenesisConfig, bc []Block) []Block {
+				bc[0].PreviousHash = strings.Repeat("1", 64)
+				bc[0] = remine(cfg, bc[0])
+				return bc[:1]
+			},
+			wantRule:  "hash-link",
+			wantBlock: "Genesis",
+		},
+		{
+			name:  "block linked to the wrong parent",
+			level: verifyParanoid,
+			tamper: func(cfg GenesisConfig, bc []Block) []Block {
+				bc[3].PreviousHash = bc[1].Hash
+				bc[3] = remine(cfg, bc[3])
+				return bc[:4]
+			},
+			wantRule:  "hash-link",
+			wantBlock: "block 3",
+		},
+		{
+			name:  "difficulty claimed above the hash",
+			level: verifyQuick,
+			tamper: func(cfg GenesisConfig, bc []Block) []Block {
+				bc[2].Difficulty = 8
+				return bc
+			},
+			wantRule:  "difficulty",
+			wantBlock: "Block 2",
+		},
+		{
+			name:   "difficulty against the retarget algorithm",
+			level:  verifyStandard,
+			config: func(cfg *GenesisConfig) { cfg.Retarget = "fixed" },
+			tamper: func(cfg GenesisConfig, bc []Block) []Block {
+				bc[4].Difficulty = 2
+				bc[4] = remine(cfg, bc[4])
+				return bc[:5]
+			},
+			wantRule:  "retarget",
+			wantBlock: "Block 4",
+		},
+		{
+			name:      "data over max_data_size",
+			level:     verifyQuick,
+			config:    func(cfg *GenesisConfig) { cfg.MaxDataSize = 4 },
+			wantRule:  "size",
+			wantBlock: "Block 1",
+		},
+		{
+			name:  "extra data over the consensus limit",
+			level: verifyQuick,
+			tamper: func(cfg GenesisConfig, bc []Block) []Block {
+				bc[5].ExtraData = strings.Repeat("x", maxExtraDataSize+1)
+				bc[5] = remine(cfg, bc[5])
+				return bc
+			},
+			wantRule:  "extra-data",
+			wantBlock: "Block 5",
+		},
+		{
+			name:  "timestamp not after median time past",
+			level: verifyQuick,
+			tamper: func(cfg GenesisConfig, bc []Block) []Block {
+				bc[5].Timestamp = bc[1].Timestamp
+				bc[5] = remine(cfg, bc[5])
+				return bc
+			},
+			wantRule:  "timestamp",
+			wantBlock: "block 5",
+		},
+		{
+			name:  "timestamp far in the future",
+			level: verifyQuick,
+			tamper: func(cfg GenesisConfig, bc []Block) []Block {
+				bc[5].Timestamp = time.Now().Add(24 * time.Hour).Format(time.RFC3339)
+				bc[5] = remine(cfg, bc[5])
+				return bc
+			},
+			wantRule:  "timestamp",
+			wantBlock: "block 5",
+		},
+		{
+			name:  "slashing evidence on a pow chain",
+			level: verifyParanoid,
+			tamper: func(cfg GenesisConfig, bc []Block) []Block {
+				bc[5].Evidence = []DoubleSignEvidence{{Validator: "mallory", Index: 1, HashA: "aa", HashB: "bb"}}
+				bc[5] = remine(cfg, bc[5])
+				return bc
+			},
+			wantRule:  "evidence",
+			wantBlock: "Block 5",
+		},
+		{
+			name:  "evidence stripped after mining",
+			level: verifyStandard,
+			tamper: func(cfg GenesisConfig, bc []Block) []Block {
+				bc[5].Evidence = []DoubleSignEvidence{{Validator: "mallory", Index: 1, HashA: "aa", HashB: "bb"}}
+				bc[5] = remine(cfg, bc[5])
+				bc[5].Evidence = nil
+				return bc
+			},
+			wantRule:  "hash",
+			wantBlock: "block 5",
+		},
+		{
+			name:  "validator signatures on a pow chain",
+			level: verifyParanoid,
+			tamper: func(cfg GenesisConfig, bc []Block) []Block {
+				bc[5].Signatures = []ValidatorSignature{{Validator: "mallory", Signature: "00"}}
+				return bc
+			},
+			wantRule:  "finality",
+			wantBlock: "Block 5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := fixtureGenesisConfig()
+			if tt.config != nil {
+				tt.config(&cfg)
+			}
+			blockchain := fixtureChain(cfg, 1, 6, 1)
+			if tt.tamper != nil {
+				blockchain = tt.tamper(cfg, blockchain)
+			}
+
+			_, rule, err := verifyChain(cfg, blockchain, tt.level)
+			if tt.wantRule == "" {
+				if err != nil {
+					t.Fatalf("verifyChain: [%s] %v, want a valid chain", rule, err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("verifyChain accepted the chain, want rule %s to fail", tt.wantRule)
+			}
+			if rule != tt.wantRule {
+				t.Errorf("failed rule = %s (%v), want %s", rule, err, tt.wantRule)
+			}
+			if !strings.Contains(err.Error(), tt.wantBlock) {
+				t.Errorf("error %q does not mention %q", err, tt.wantBlock)
+			}
+		})
+	}
+}
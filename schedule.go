@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// blockSchedule submits the same block request to the mining queue every EveryBlocks blocks
+// or every Interval, producing continuous traffic in long simulations
+type blockSchedule struct {
+	ID          int    `json:"id"`
+	Data        string `json:"data"`
+	EveryBlocks int    `json:"every_blocks,omitempty"`
+	Interval    string `json:"interval,omitempty"` // Durasi Go, misalnya "30s"
+	Count       int    `json:"count,omitempty"`    // Jumlah pengiriman, 0 berarti sampai dibatalkan
+	Submitted   int    `json:"submitted"`
+
+	interval time.Duration
+	cancel   context.CancelFunc
+}
+
+// validate checks that exactly one trigger is set, parses the interval and checks the data
+// against the size limit of cfg
+func (s *blockSchedule) validate(cfg GenesisConfig) error {
+	if s.Data == "" {
+		return fmt.Errorf("jadwal harus memiliki data")
+	}
+	if err := cfg.checkDataSize(s.Data); err != nil {
+		return err
+	}
+	if s.Count < 0 || s.EveryBlocks < 0 {
+		return fmt.Errorf("count dan every_blocks tidak boleh negatif")
+	}
+	if (s.EveryBlocks > 0) == (s.Interval != "") {
+		return fmt.Errorf("jadwal harus memiliki tepat satu dari every_blocks atau interval")
+	}
+	if s.Interval != "" {
+		interval, err := time.ParseDuration(s.Interval)
+		if err != nil || interval <= 0 {
+			return fmt.Errorf("interval jadwal tidak valid: %q", s.Interval)
+		}
+		s.interval = interval
+	}
+	return nil
+}
+
+// blockScheduler runs the block schedules of a node
+type blockScheduler struct {
+	mu        sync.Mutex
+	queue     *miningQueue
+	schedules map[int]*blockSchedule
+	nextID    int
+	ctx       context.Context // Diisi oleh run; jadwal interval berhenti bersama node
+}
+
+// loadSchedules reads a JSON array of {"data", "every_blocks"|"interval", "count"} objects
+// for a chain with genesis config cfg
+func loadSchedules(cfg GenesisConfig, path string) ([]blockSchedule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var schedules []blockSchedule
+	if err := json.Unmarshal(data, &schedules); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i := range schedules {
+		if err := schedules[i].validate(cfg); err != nil {
+			return nil, fmt.Errorf("%s: jadwal ke-%d: %w", path, i+1, err)
+		}
+	}
+	return schedules, nil
+}
+
+// newBlockScheduler creates a scheduler holding the initial schedules; they start with run
+func newBlockScheduler(queue *miningQueue, initial []blockSchedule) *blockScheduler {
+	s := &blockScheduler{queue: queue, schedules: make(map[int]*blockSchedule), nextID: 1}
+	for _, schedule := range initial {
+		schedule.ID = s.nextID
+		s.schedules[schedule.ID] = &schedule
+		s.nextID++
+	}
+	return s
+}
+
+// Add registers a schedule at runtime
+func (s *blockScheduler) Add(schedule blockSchedule) (blockSchedule, error) {
+	if err := schedule.validate(s.queue.chain.Config()); err != nil {
+		return blockSchedule{}, err
+	}
+	schedule.Submitted = 0
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx == nil {
+		return blockSchedule{}, fmt.Errorf("penjadwal belum berjalan")
+	}
+	schedule.ID = s.nextID
+	s.nextID++
+	s.schedules[schedule.ID] = &schedule
+	s.startLocked(&schedule)
+	return schedule, nil
+}
+
+// Cancel stops and removes a schedule
+func (s *blockScheduler) Cancel(id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schedule, ok := s.schedules[id]
+	if !ok {
+		return false
+	}
+	if schedule.cancel != nil {
+		schedule.cancel()
+	}
+	delete(s.schedules, id)
+	return true
+}
+
+// Schedules returns a snapshot of every schedule ordered by ID
+func (s *blockScheduler) Schedules() []blockSchedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schedules := make([]blockSchedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		schedules = append(schedules, *schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].ID < schedules[j].ID })
+	return schedules
+}
+
+// startLocked starts the ticker of an interval schedule; block schedules are driven by run
+func (s *blockScheduler) startLocked(schedule *blockSchedule) {
+	if schedule.interval == 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	schedule.cancel = cancel
+	go func() {
+		ticker := time.NewTicker(schedule.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.fire(schedule.ID)
+			}
+		}
+	}()
+}
+
+// fire submits one block request of a schedule and removes the schedule once Count is reached
+func (s *blockScheduler) fire(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	schedule, ok := s.schedules[id]
+	if !ok {
+		return
+	}
+	if _, err := s.queue.Submit(schedule.Data, logMiningObserver{prefix: "jadwal " + strconv.Itoa(id) + ": "}, ""); err != nil {
+		fmt.Printf(Yellow+"Jadwal %d tidak dapat mengirim blok: %v\n"+Reset, id, err)
+		return
+	}
+	schedule.Submitted++
+	if schedule.Count > 0 && schedule.Submitted >= schedule.Count {
+		if schedule.cancel != nil {
+			schedule.cancel()
+		}
+		delete(s.schedules, id)
+	}
+}
+
+// run starts the schedules and submits block schedules on every matching new block until ctx is cancelled
+func (s *blockScheduler) run(ctx context.Context, bus *eventBus) {
+	s.mu.Lock()
+	s.ctx = ctx
+	for _, schedule := range s.schedules {
+		s.startLocked(schedule)
+	}
+	s.mu.Unlock()
+
+	events, unsubscribe := bus.Subscribe()
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+	}()
+	for event := range events {
+		if event.Type != eventBlock || event.Block == nil {
+			continue
+		}
+		for _, schedule := range s.Schedules() {
+			if schedule.EveryBlocks > 0 && event.Block.Index%schedule.EveryBlocks == 0 {
+				s.fire(schedule.ID)
+			}
+		}
+	}
+}
+
+func (s *apiServer) handleListSchedules(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.schedules.Schedules())
+}
+
+// handleAddSchedule registers {"data", "every_blocks"|"interval", "count"} until the node restarts
+func (s *apiServer) handleAddSchedule(w http.ResponseWriter, r *http.Request) {
+	var schedule blockSchedule
+	if err := json.NewDecoder(r.Body).Decode(&schedule); err != nil {
+		writeError(w, http.StatusBadRequest, "body harus berupa JSON {\"data\": \"...\", \"every_blocks\": 5} atau {\"data\": \"...\", \"interval\": \"30s\"}")
+		return
+	}
+	schedule, err := s.schedules.Add(schedule)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, schedule)
+}
+
+func (s *apiServer) handleCancelSchedule(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || !s.schedules.Cancel(id) {
+		writeError(w, http.StatusNotFound, "jadwal tidak ditemukan")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
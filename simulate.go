@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// expectedHashes returns the expected number of hash attempts needed to find a block,
+// since every leading hex zero divides the chance of success by 16
+func expectedHashes(difficulty int) float64 {
+	return math.Pow(16, float64(difficulty))
+}
+
+// sampleBlockTime draws a block discovery time in seconds; for work expected hashes
+// at hashrate hashes per second, discovery is a Poisson process with exponential gaps
+func sampleBlockTime(rng *rand.Rand, work, hashrate float64) float64 {
+	return rng.ExpFloat64() * work / hashrate
+}
+
+// durationSeconds formats a number of seconds as a rounded time.Duration
+func durationSeconds(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Millisecond)
+}
+
+func runSimulateCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	blocks := fs.Int("blocks", 1000, "Jumlah blok yang disimulasikan")
+	difficulty := fs.Int("difficulty", 5, "Tingkat kesulitan (jumlah nol hex di awal hash)")
+	hashrate := fs.Float64("hashrate", 1e6, "Hashrate yang disimulasikan (hash per detik)")
+	seed := fs.Int64("seed", time.Now().UnixNano(), "Seed generator acak")
+	csvPath := fs.String("csv", "", "Simpan waktu setiap blok ke file CSV")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *blocks < 1 || *hashrate <= 0 || *difficulty < 0 || *difficulty > 64 {
+		return fmt.Errorf("blocks harus >= 1, hashrate > 0, dan difficulty antara 0 dan 64")
+	}
+
+	rng := rand.New(rand.NewSource(*seed))
+	work := expectedHashes(*difficulty)
+	intervals := make([]float64, *blocks)
+	var total float64
+	for i := range intervals {
+		intervals[i] = sampleBlockTime(rng, work, *hashrate)
+		total += intervals[i]
+	}
+
+	if *csvPath != "" {
+		if err := writeIntervalsCSV(*csvPath, intervals); err != nil {
+			return err
+		}
+	}
+
+	mean := total / float64(len(intervals))
+	var variance float64
+	for _, interval := range intervals {
+		variance += (interval - mean) * (interval - mean)
+	}
+	stddev := math.Sqrt(variance / float64(len(intervals)))
+
+	sorted := append([]float64(nil), intervals...)
+	sort.Float64s(sorted)
+	percentile := func(p float64) float64 {
+		return sorted[int(p*float64(len(sorted)-1))]
+	}
+
+	fmt.Println(BoldYellow + "\n=== Simulasi Waktu Mining (tanpa hashing) ===" + Reset)
+	fmt.Printf("%sBlok             :%s %d\n", BoldCyan, Reset, *blocks)
+	fmt.Printf("%sDifficulty       :%s %d (%.0f hash per blok)\n", BoldCyan, Reset, *difficulty, work)
+	fmt.Printf("%sHashrate         :%s %.0f H/s\n", BoldCyan, Reset, *hashrate)
+	fmt.Printf("%sSeed             :%s %d\n", BoldCyan, Reset, *seed)
+	fmt.Printf("%sRata-rata teori  :%s %s\n", BoldCyan, Reset, durationSeconds(work / *hashrate))
+	fmt.Printf("%sRata-rata        :%s %s\n", BoldCyan, Reset, durationSeconds(mean))
+	fmt.Printf("%sStandar deviasi  :%s %s\n", BoldCyan, Reset, durationSeconds(stddev))
+	fmt.Printf("%sMin/Median/Max   :%s %s / %s / %s\n", BoldCyan, Reset, durationSeconds(sorted[0]), durationSeconds(percentile(0.5)), durationSeconds(sorted[len(sorted)-1]))
+	fmt.Printf("%sP90/P99          :%s %s / %s\n", BoldCyan, Reset, durationSeconds(percentile(0.9)), durationSeconds(percentile(0.99)))
+	fmt.Printf("%sTotal waktu      :%s %s\n", BoldCyan, Reset, durationSeconds(total))
+	return nil
+}
+
+// writeIntervalsCSV writes one row per simulated block with its discovery time in seconds
+func writeIntervalsCSV(path string, intervals []float64) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"block", "interval_seconds"})
+	for i, interval := range intervals {
+		writer.Write([]string{strconv.Itoa(i + 1), strconv.FormatFloat(interval, 'f', 6, 64)})
+	}
+	writer.Flush()
+	return writer.Error()
+}
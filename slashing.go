@@ -0,0 +1,155 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// DoubleSignEvidence proves that a validator signed two different blocks at the same height
+type DoubleSignEvidence struct {
+	Validator  string `json:"validator"`
+	Index      int    `json:"index"`
+	HashA      string `json:"hash_a"`
+	SignatureA string `json:"signature_a"`
+	HashB      string `json:"hash_b"`
+	SignatureB string `json:"signature_b"`
+}
+
+// evidenceDigest is the hex SHA-256 of the JSON encoding of evidence, hashed into the block that
+// includes it so evidence cannot be added to or stripped from a mined block
+func evidenceDigest(evidence []DoubleSignEvidence) string {
+	data, _ := json.Marshal(evidence)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// verifyEvidence checks that both signatures are valid finality signatures of the validator over conflicting hashes
+func verifyEvidence(validators []Validator, evidence DoubleSignEvidence) error {
+	if evidence.HashA == evidence.HashB {
+		return fmt.Errorf("evidence against %q signs the same hash twice", evidence.Validator)
+	}
+
+	var publicKey ed25519.PublicKey
+	for _, validator := range validators {
+		if validator.Name == evidence.Validator {
+			publicKey, _ = hex.DecodeString(validator.PublicKey)
+		}
+	}
+	if len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("evidence names unknown validator %q", evidence.Validator)
+	}
+
+	for _, signed := range []struct{ hash, signature string }{
+		{evidence.HashA, evidence.SignatureA},
+		{evidence.HashB, evidence.SignatureB},
+	} {
+		message := finalityMessage(Block{Index: evidence.Index, Hash: signed.hash})
+		signature, err := hex.DecodeString(signed.signature)
+		if err != nil || !ed25519.Verify(publicKey, message, signature) {
+			return fmt.Errorf("evidence against %q has an invalid signature", evidence.Validator)
+		}
+	}
+	return nil
+}
+
+// slashedValidators returns the validators convicted by evidence included in blocks
+func slashedValidators(blocks []Block) map[string]bool {
+	slashed := make(map[string]bool)
+	for _, block := range blocks {
+		for _, evidence := range block.Evidence {
+			slashed[evidence.Validator] = true
+		}
+	}
+	return slashed
+}
+
+// activeValidators applies the delegations and slashing in blocks to the genesis validator
+// set: a validator convicted of double signing loses its whole stake, including what was
+// delegated to it, and no longer counts towards finality
+func activeValidators(validators []Validator, blocks []Block) []Validator {
+	slashed := slashedValidators(blocks)
+	active := make([]Validator, len(validators))
+	for i, validator := range validators {
+		active[i] = validator
+		active[i].Delegations = append([]Delegation(nil), validator.Delegations...)
+	}
+	for _, delegation := range chainDelegations(blocks) {
+		for i := range active {
+			if active[i].Name == delegation.Validator {
+				active[i].Delegations = append(active[i].Delegations, Delegation{Delegator: delegation.Delegator, Amount: delegation.Amount})
+			}
+		}
+	}
+	for i := range active {
+		if slashed[active[i].Name] {
+			active[i].Stake = 0
+			active[i].Delegations = nil
+		}
+	}
+	return active
+}
+
+// checkEvidence verifies every piece of evidence in a block and rejects evidence against
+// validators that were already slashed
+func checkEvidence(cfg GenesisConfig, blockchain []Block, i int) error {
+	block := blockchain[i]
+	if len(block.Evidence) == 0 {
+		return nil
+	}
+	if cfg.Consensus != consensusHybrid {
+		return fmt.Errorf("Block %d carries slashing evidence on a %s chain", block.Index, cfg.Consensus)
+	}
+
+	slashed := slashedValidators(blockchain[:i])
+	for _, evidence := range block.Evidence {
+		if err := verifyEvidence(cfg.Validators, evidence); err != nil {
+			return fmt.Errorf("Block %d: %v", block.Index, err)
+		}
+		if slashed[evidence.Validator] {
+			return fmt.Errorf("Block %d slashes validator %q twice", block.Index, evidence.Validator)
+		}
+		slashed[evidence.Validator] = true
+	}
+	return nil
+}
+
+// forgeDoubleSign signs two conflicting hashes at index with a local validator key, producing
+// evidence for slashing experiments
+func forgeDoubleSign(name string, key ed25519.PrivateKey, index int) DoubleSignEvidence {
+	evidence := DoubleSignEvidence{Validator: name, Index: index}
+	for i, hash := range []*string{&evidence.HashA, &evidence.HashB} {
+		*hash = hex.EncodeToString([]byte(fmt.Sprintf("%032d", i)))
+	}
+	evidence.SignatureA = hex.EncodeToString(ed25519.Sign(key, finalityMessage(Block{Index: index, Hash: evidence.HashA})))
+	evidence.SignatureB = hex.EncodeToString(ed25519.Sign(key, finalityMessage(Block{Index: index, Hash: evidence.HashB})))
+	return evidence
+}
+
+// runDoubleSignCommand implements "validator doublesign <dir> <nama> <index>"
+func runDoubleSignCommand(store BlockStore, args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("penggunaan: validator doublesign <dir> <nama> <index>")
+	}
+	index, err := strconv.Atoi(args[2])
+	if err != nil || index < 0 {
+		return fmt.Errorf("index harus berupa angka non-negatif")
+	}
+	keys, _, err := loadValidatorKeys(args[0])
+	if err != nil {
+		return err
+	}
+	key, ok := keys[args[1]]
+	if !ok {
+		return fmt.Errorf("key validator %q tidak ditemukan di %s", args[1], args[0])
+	}
+
+	// Evidence dicetak sebagai JSON agar bisa dikirim ke POST /evidence
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(forgeDoubleSign(args[1], key, index))
+}
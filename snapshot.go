@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// snapshotsDir is the directory inside the chain directory that holds the snapshots
+const snapshotsDir = "snapshots"
+
+// defaultSnapshotKeep is how many snapshots are kept when -snapshot-keep is not given
+const defaultSnapshotKeep = 5
+
+// snapshotPath returns the directory of the snapshot taken at height
+func (s fileBlockStore) snapshotPath(height int) string {
+	return filepath.Join(s.dir, snapshotsDir, fmt.Sprintf("%08d", height))
+}
+
+// copyStoreFile copies one file byte for byte, so encrypted blocks stay encrypted
+func copyStoreFile(from, to string) error {
+	data, err := os.ReadFile(from)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(to, data, 0644)
+}
+
+// Snapshot copies genesis.json, the payload schemas and blocks 0..height into snapshots/<height>
+func (s fileBlockStore) Snapshot(height int) error {
+	target := s.snapshotPath(height)
+	tmp := target + ".tmp"
+	if err := os.RemoveAll(tmp); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tmp, os.ModePerm); err != nil {
+		return err
+	}
+	schemas, err := s.schemaFiles()
+	if err != nil {
+		return err
+	}
+	names := append([]string{genesisConfigFile}, schemas...)
+	for i := 0; i <= height; i++ {
+		names = append(names, fmt.Sprintf("block%d.json", i))
+	}
+	for _, name := range names {
+		if err := copyStoreFile(filepath.Join(s.dir, name), filepath.Join(tmp, name)); err != nil && !(name == genesisConfigFile && os.IsNotExist(err)) {
+			os.RemoveAll(tmp)
+			return err
+		}
+	}
+	// Snapshot baru terlihat setelah lengkap
+	if err := os.RemoveAll(target); err != nil {
+		return err
+	}
+	return os.Rename(tmp, target)
+}
+
+// Snapshots returns the heights of the available snapshots in ascending order
+func (s fileBlockStore) Snapshots() ([]int, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, snapshotsDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var heights []int
+	for _, entry := range entries {
+		if height, err := strconv.Atoi(entry.Name()); err == nil && entry.IsDir() {
+			heights = append(heights, height)
+		}
+	}
+	sort.Ints(heights)
+	return heights, nil
+}
+
+// PruneSnapshots removes all but the keep most recent snapshots
+func (s fileBlockStore) PruneSnapshots(keep int) error {
+	heights, err := s.Snapshots()
+	if err != nil {
+		return err
+	}
+	for len(heights) > keep {
+		if err := os.RemoveAll(s.snapshotPath(heights[0])); err != nil {
+			return err
+		}
+		heights = heights[1:]
+	}
+	return nil
+}
+
+// RestoreSnapshot replaces the chain with the snapshot taken at height; blocks above it are removed
+func (s fileBlockStore) RestoreSnapshot(height int) error {
+	source := s.snapshotPath(height)
+	if _, err := os.Stat(source); err != nil {
+		return fmt.Errorf("snapshot pada tinggi %d tidak ditemukan", height)
+	}
+	blocks, err := s.LoadBlocks()
+	if err != nil {
+		return err
+	}
+
+	files, err := filepath.Glob(filepath.Join(source, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := copyStoreFile(file, filepath.Join(s.dir, filepath.Base(file))); err != nil {
+			return err
+		}
+	}
+	for _, block := range blocks {
+		if block.Index > height {
+			if err := os.Remove(filepath.Join(s.dir, fmt.Sprintf("block%d.json", block.Index))); err != nil {
+				return err
+			}
+		}
+	}
+	// Status mining yang tersimpan merujuk ke tip lama
+	os.Remove(filepath.Join(s.dir, miningStateFile))
+	return nil
+}
+
+// runSnapshots snapshots the chain every `every` blocks and keeps the last keep snapshots, until ctx ends
+func runSnapshots(ctx context.Context, chain *Chain, store fileBlockStore, every, keep int) {
+	events, unsubscribe := chain.events.Subscribe()
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+	}()
+	for event := range events {
+		if event.Type != eventBlock || event.Block == nil || event.Block.Index%every != 0 {
+			continue
+		}
+		if err := store.Snapshot(event.Block.Index); err != nil {
+			fmt.Printf(Yellow+"Snapshot pada tinggi %d gagal: %v\n"+Reset, event.Block.Index, err)
+			continue
+		}
+		if err := store.PruneSnapshots(keep); err != nil {
+			fmt.Printf(Yellow+"Snapshot lama tidak dapat dihapus: %v\n"+Reset, err)
+		}
+	}
+}
+
+// runSnapshotCommand implements "snapshot list|create|restore <height>"
+func runSnapshotCommand(store BlockStore, args []string) error {
+	files, ok := store.(fileBlockStore)
+	if !ok {
+		return fmt.Errorf("snapshot hanya tersedia untuk chain di disk yang dapat ditulis")
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("penggunaan: snapshot list|create|restore <height>")
+	}
+
+	switch args[0] {
+	case "list":
+		heights, err := files.Snapshots()
+		if err != nil {
+			return err
+		}
+		if len(heights) == 0 {
+			fmt.Println(Yellow + "Belum ada snapshot." + Reset)
+		}
+		for _, height := range heights {
+			info, err := os.Stat(files.snapshotPath(height))
+			if err != nil {
+				continue
+			}
+			fmt.Printf("%s%8d%s  %s  %s\n", BoldCyan, height, Reset, info.ModTime().Format("2006-01-02 15:04:05"), files.snapshotPath(height))
+		}
+		return nil
+
+	case "create":
+		fs := flag.NewFlagSet("snapshot create", flag.ContinueOnError)
+		keep := fs.Int("keep", defaultSnapshotKeep, "Jumlah snapshot terbaru yang disimpan")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		blocks, err := files.LoadBlocks()
+		if err != nil {
+			return err
+		}
+		if len(blocks) == 0 {
+			return fmt.Errorf("blockchain lokal kosong")
+		}
+		height := blocks[len(blocks)-1].Index
+		if err := files.Snapshot(height); err != nil {
+			return err
+		}
+		if err := files.PruneSnapshots(*keep); err != nil {
+			return err
+		}
+		fmt.Printf(Green+"Snapshot pada tinggi %d dibuat di %s.\n"+Reset, height, files.snapshotPath(height))
+		return nil
+
+	case "restore":
+		if len(args) != 2 {
+			return fmt.Errorf("penggunaan: snapshot restore <height>")
+		}
+		height, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("height harus berupa angka")
+		}
+		if err := files.RestoreSnapshot(height); err != nil {
+			return err
+		}
+		fmt.Printf(Green+"Blockchain dikembalikan ke snapshot pada tinggi %d.\n"+Reset, height)
+		return nil
+
+	default:
+		return fmt.Errorf("perintah snapshot tidak dikenal: %q", args[0])
+	}
+}
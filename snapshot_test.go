@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	cfg := fixtureGenesisConfig()
+	blockchain := fixtureChain(cfg, 1, 6, 1)
+	store := testStore(t, blockchain[:3])
+	if err := store.WriteFile(genesisConfigFile, []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.WriteFile(schemaFile("order"), []byte(`{"required": ["sku"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Snapshot(2); err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	for _, block := range blockchain[3:] {
+		if err := store.SaveBlock(block); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.WriteFile(miningStateFile, []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Remove(schemaFile("order")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.RestoreSnapshot(2); err != nil {
+		t.Fatalf("RestoreSnapshot: %v", err)
+	}
+	want := blockHashes(t, testStore(t, blockchain[:3]))
+	if got := blockHashes(t, store); !reflect.DeepEqual(got, want) {
+		t.Errorf("blocks after restore = %v, want %v", got, want)
+	}
+	if _, err := store.ReadFile(genesisConfigFile); err != nil {
+		t.Errorf("%s not restored: %v", genesisConfigFile, err)
+	}
+	if _, err := loadPayloadSchema(store, "order"); err != nil {
+		t.Errorf("schema not restored: %v", err)
+	}
+	if _, err := store.ReadFile(miningStateFile); !os.IsNotExist(err) {
+		t.Errorf("%s pointing at the old tip survived the restore", miningStateFile)
+	}
+
+	if err := store.RestoreSnapshot(4); err == nil {
+		t.Errorf("RestoreSnapshot accepted a height without snapshot")
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(store.dir, snapshotsDir, "*.tmp")); len(leftovers) != 0 {
+		t.Errorf("temporary snapshot directories left behind: %v", leftovers)
+	}
+}
+
+func TestPruneSnapshots(t *testing.T) {
+	cfg := fixtureGenesisConfig()
+	store := testStore(t, fixtureChain(cfg, 1, 5, 1))
+	for height := 0; height < 5; height++ {
+		if err := store.Snapshot(height); err != nil {
+			t.Fatalf("Snapshot(%d): %v", height, err)
+		}
+	}
+	// Snapshot ulang pada tinggi yang sama menggantikan yang lama
+	if err := store.Snapshot(3); err != nil {
+		t.Fatalf("Snapshot(3) again: %v", err)
+	}
+
+	if err := store.PruneSnapshots(2); err != nil {
+		t.Fatalf("PruneSnapshots: %v", err)
+	}
+	heights, err := store.Snapshots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(heights, []int{3, 4}) {
+		t.Errorf("snapshots after pruning = %v, want [3 4]", heights)
+	}
+}
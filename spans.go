@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"sort"
+	"sync"
+	"time"
+)
+
+// spanStats aggregates every finished span with the same name
+type spanStats struct {
+	Name    string        `json:"name"`
+	Count   int           `json:"count"`
+	Total   time.Duration `json:"total_ns"`
+	Max     time.Duration `json:"max_ns"`
+	Average time.Duration `json:"average_ns"`
+}
+
+// spanRecorder collects timing spans of mining, validation, storage and sync
+type spanRecorder struct {
+	mu    sync.Mutex
+	stats map[string]*spanStats
+}
+
+// spans is the recorder of this process
+var spans = &spanRecorder{stats: make(map[string]*spanStats)}
+
+// startSpan starts timing name; call the returned function when the work ends, e.g.
+// defer startSpan("store.save")()
+func startSpan(name string) func() {
+	start := time.Now()
+	return func() { spans.record(name, time.Since(start)) }
+}
+
+func (r *spanRecorder) record(name string, elapsed time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats, ok := r.stats[name]
+	if !ok {
+		stats = &spanStats{Name: name}
+		r.stats[name] = stats
+	}
+	stats.Count++
+	stats.Total += elapsed
+	stats.Max = max(stats.Max, elapsed)
+}
+
+// Summary returns the aggregated spans, the most total time first
+func (r *spanRecorder) Summary() []spanStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	summary := make([]spanStats, 0, len(r.stats))
+	for _, stats := range r.stats {
+		entry := *stats
+		entry.Average = entry.Total / time.Duration(entry.Count)
+		summary = append(summary, entry)
+	}
+	sort.Slice(summary, func(i, j int) bool { return summary[i].Total > summary[j].Total })
+	return summary
+}
+
+// printSpanSummary prints the trace summary as a table
+func printSpanSummary() {
+	summary := spans.Summary()
+	fmt.Println(BoldYellow + "\n=== Ringkasan Waktu ===" + Reset)
+	if len(summary) == 0 {
+		fmt.Println("Belum ada span yang tercatat.")
+		return
+	}
+	fmt.Printf("%s%-16s %8s %12s %12s %12s%s\n", BoldCyan, "span", "jumlah", "total", "rata-rata", "maks", Reset)
+	for _, stats := range summary {
+		fmt.Printf("%-16s %8d %12v %12v %12v\n", stats.Name, stats.Count, stats.Total.Round(time.Microsecond), stats.Average.Round(time.Microsecond), stats.Max.Round(time.Microsecond))
+	}
+}
+
+func (s *apiServer) handleSpans(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, spans.Summary())
+}
+
+// pprofRoutes exposes net/http/pprof for admins when the node runs with -pprof
+func (s *apiServer) pprofRoutes() []apiRoute {
+	return []apiRoute{
+		{Method: "GET", Path: "/debug/pprof/", Permission: permAdmin, Summary: "Indeks profil net/http/pprof; /debug/pprof/heap, /goroutine, dan seterusnya", Handler: pprof.Index},
+		{Method: "GET", Path: "/debug/pprof/cmdline", Permission: permAdmin, Summary: "Argumen command line proses", Handler: pprof.Cmdline},
+		{Method: "GET", Path: "/debug/pprof/profile", Permission: permAdmin, Summary: "Profil CPU; ?seconds= menentukan lamanya", Handler: pprof.Profile},
+		{Method: "GET", Path: "/debug/pprof/symbol", Permission: permAdmin, Summary: "Pencarian simbol untuk pprof", Handler: pprof.Symbol},
+		{Method: "GET", Path: "/debug/pprof/trace", Permission: permAdmin, Summary: "Execution trace; ?seconds= menentukan lamanya", Handler: pprof.Trace},
+	}
+}
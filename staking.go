@@ -0,0 +1,304 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// delegationsFile lists the delegations of a validator directory (<dir>/delegations.json)
+const delegationsFile = "delegations.json"
+
+// delegationDataPrefix starts the Data of a block that delegates stake on a running hybrid
+// chain: "delegate:<delegator>:<validator>:<jumlah>"
+const delegationDataPrefix = "delegate:"
+
+// Delegation is stake an account lends to a validator; it counts towards the validator's
+// voting power and earns a share of the validator's rewards. Delegations made before a chain
+// exists are read from delegations.json into genesis.json; on a running chain they are mined
+// as blocks whose Data is a delegation and take effect like slashing, from the next epoch.
+type Delegation struct {
+	Delegator string `json:"delegator"`
+	Validator string `json:"validator,omitempty"` // Hanya dipakai di delegations.json
+	Amount    uint64 `json:"amount"`
+}
+
+// delegationData returns the block Data that delegates amount from delegator to validator
+func delegationData(delegator, validator string, amount uint64) string {
+	return delegationDataPrefix + delegator + ":" + validator + ":" + strconv.FormatUint(amount, 10)
+}
+
+// parseDelegation decodes the Data of a delegation block; ok is false for any other data
+func parseDelegation(data string) (delegation Delegation, ok bool, err error) {
+	rest, ok := strings.CutPrefix(data, delegationDataPrefix)
+	if !ok {
+		return Delegation{}, false, nil
+	}
+	parts := strings.Split(rest, ":")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || strings.ContainsAny(parts[0], " \t\n") {
+		return Delegation{}, true, fmt.Errorf("delegation must be %s<delegator>:<validator>:<amount>", delegationDataPrefix)
+	}
+	amount, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil || amount == 0 {
+		return Delegation{}, true, fmt.Errorf("delegation amount must be a positive integer")
+	}
+	return Delegation{Delegator: parts[0], Validator: parts[1], Amount: amount}, true, nil
+}
+
+// chainDelegations returns the valid delegations mined in blocks, in chain order
+func chainDelegations(blocks []Block) []Delegation {
+	var delegations []Delegation
+	for _, block := range blocks {
+		if delegation, ok, err := parseDelegation(block.Data); ok && err == nil {
+			delegations = append(delegations, delegation)
+		}
+	}
+	return delegations
+}
+
+// checkDelegation verifies that a delegation block of a hybrid chain names a validator that
+// exists and has not been slashed
+func checkDelegation(cfg GenesisConfig, blockchain []Block, i int) error {
+	block := blockchain[i]
+	if cfg.Consensus != consensusHybrid {
+		return nil
+	}
+	delegation, ok, err := parseDelegation(block.Data)
+	if !ok {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Block %d: %v", block.Index, err)
+	}
+	known := false
+	for _, validator := range cfg.Validators {
+		known = known || validator.Name == delegation.Validator
+	}
+	if !known {
+		return fmt.Errorf("Block %d delegates to unknown validator %q", block.Index, delegation.Validator)
+	}
+	if slashedValidators(blockchain[:i])[delegation.Validator] {
+		return fmt.Errorf("Block %d delegates to slashed validator %q", block.Index, delegation.Validator)
+	}
+	return nil
+}
+
+// power returns the validator's own stake plus everything delegated to it
+func (v Validator) power() uint64 {
+	power := v.Stake
+	for _, delegation := range v.Delegations {
+		power += delegation.Amount
+	}
+	return power
+}
+
+// loadDelegations reads delegations.json from a validator directory; a missing file means no delegations
+func loadDelegations(dir string) ([]Delegation, error) {
+	data, err := os.ReadFile(filepath.Join(dir, delegationsFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var delegations []Delegation
+	if err := json.Unmarshal(data, &delegations); err != nil {
+		return nil, fmt.Errorf("%s: %w", delegationsFile, err)
+	}
+	return delegations, nil
+}
+
+// attachDelegations adds each delegation to the validator it names
+func attachDelegations(validators []Validator, delegations []Delegation) error {
+	for _, delegation := range delegations {
+		found := false
+		for i := range validators {
+			if validators[i].Name == delegation.Validator {
+				validators[i].Delegations = append(validators[i].Delegations, Delegation{Delegator: delegation.Delegator, Amount: delegation.Amount})
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: delegasi %s ke validator %q yang tidak ada", delegationsFile, delegation.Delegator, delegation.Validator)
+		}
+	}
+	return nil
+}
+
+// blockRewards splits reward of a finalized block among its signers by voting power; each
+// validator keeps its commission and shares the rest with its delegators by stake
+func blockRewards(validators []Validator, block Block, reward float64) map[string]float64 {
+	byName := make(map[string]Validator, len(validators))
+	for _, validator := range validators {
+		byName[validator.Name] = validator
+	}
+
+	var signedPower uint64
+	for _, sig := range block.Signatures {
+		signedPower += byName[sig.Validator].power()
+	}
+	rewards := make(map[string]float64)
+	if signedPower == 0 {
+		return rewards
+	}
+
+	for _, sig := range block.Signatures {
+		validator := byName[sig.Validator]
+		power := validator.power()
+		if power == 0 {
+			continue
+		}
+		share := reward * float64(power) / float64(signedPower)
+		commission := share * validator.Commission
+		rest := share - commission
+
+		rewards[validator.Name] += commission + rest*float64(validator.Stake)/float64(power)
+		for _, delegation := range validator.Delegations {
+			rewards[delegation.Delegator] += rest * float64(delegation.Amount) / float64(power)
+		}
+	}
+	return rewards
+}
+
+// runDelegateCommand implements "validator delegate [-validators dir] <delegator> <validator>
+// <jumlah>", which mines a delegation block onto the chain in -datadir, and "validator delegate
+// <dir> <delegator> <validator> <jumlah>", which edits delegations.json for the next chain
+// created from dir
+func runDelegateCommand(store BlockStore, args []string) error {
+	if len(args) == 4 {
+		return runGenesisDelegateCommand(args)
+	}
+	fs := flag.NewFlagSet("validator delegate", flag.ContinueOnError)
+	validatorDir := fs.String("validators", "", "Direktori key validator untuk memfinalisasi blok delegasi")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 3 {
+		return fmt.Errorf("penggunaan: validator delegate [-validators dir] <delegator> <validator> <jumlah> | validator delegate <dir> <delegator> <validator> <jumlah>")
+	}
+
+	cfg, blockchain, err := loadChain(store)
+	if err != nil {
+		return err
+	}
+	if cfg.Consensus != consensusHybrid {
+		return fmt.Errorf("delegasi hanya tersedia pada konsensus %s", consensusHybrid)
+	}
+	amount, err := strconv.ParseUint(fs.Arg(2), 10, 64)
+	if err != nil || amount == 0 {
+		return fmt.Errorf("jumlah delegasi harus berupa angka positif")
+	}
+	data := delegationData(fs.Arg(0), fs.Arg(1), amount)
+	candidate := append(blockchain[:len(blockchain):len(blockchain)], Block{Index: len(blockchain), Data: data})
+	if err := checkDelegation(cfg, candidate, len(candidate)-1); err != nil {
+		return err
+	}
+
+	mineArgs := []string{data}
+	if *validatorDir != "" {
+		mineArgs = []string{"-validators", *validatorDir, data}
+	}
+	if err := runMineCommand(store, mineArgs); err != nil {
+		return err
+	}
+	fmt.Printf(Green+"%s mendelegasikan %d ke validator %s.\n"+Reset, fs.Arg(0), amount, fs.Arg(1))
+	if cfg.EpochLength > 0 {
+		fmt.Printf(Yellow+"Delegasi berlaku mulai epoch berikutnya (blok %d).\n"+Reset, (epochOf(cfg, len(blockchain))+1)*cfg.EpochLength)
+	}
+	return nil
+}
+
+// runGenesisDelegateCommand implements "validator delegate <dir> <delegator> <validator> <jumlah>"
+func runGenesisDelegateCommand(args []string) error {
+	amount, err := strconv.ParseUint(args[3], 10, 64)
+	if err != nil || amount == 0 {
+		return fmt.Errorf("jumlah delegasi harus berupa angka positif")
+	}
+	if _, err := os.Stat(filepath.Join(args[0], args[2]+".key")); err != nil {
+		return fmt.Errorf("validator %q tidak ditemukan di %s", args[2], args[0])
+	}
+
+	delegations, err := loadDelegations(args[0])
+	if err != nil {
+		return err
+	}
+	delegations = append(delegations, Delegation{Delegator: args[1], Validator: args[2], Amount: amount})
+	data, err := json.MarshalIndent(delegations, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(args[0], delegationsFile), append(data, '\n'), 0600); err != nil {
+		return err
+	}
+	fmt.Printf(Green+"%s mendelegasikan %d ke validator %s.\n"+Reset, args[1], amount, args[2])
+	fmt.Printf(Yellow+"Delegasi ini masuk ke genesis chain baru yang dibuat dari %s; untuk chain yang sudah berjalan gunakan validator delegate tanpa <dir>.\n"+Reset, args[0])
+	return nil
+}
+
+// runRewardsCommand implements "validator rewards": the earnings of every validator and delegator per epoch
+func runRewardsCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("validator rewards", flag.ContinueOnError)
+	epochLength := fs.Int("epoch-length", 0, "Jumlah blok per epoch dalam laporan (0 berarti epoch chain, atau 10 jika chain tanpa epoch)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadGenesisConfig(store)
+	if err != nil {
+		return err
+	}
+	if *epochLength == 0 {
+		*epochLength = cfg.EpochLength
+	}
+	if *epochLength == 0 {
+		*epochLength = defaultEpochLength
+	}
+	if *epochLength < 1 {
+		return fmt.Errorf("-epoch-length harus >= 1")
+	}
+	if cfg.Consensus != consensusHybrid {
+		return fmt.Errorf("reward staking hanya tersedia pada konsensus %s", consensusHybrid)
+	}
+	blocks, err := loadBlockchain(store)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(BoldYellow+"\n=== Reward Staking (%.0f per blok, %d blok per epoch) ===\n"+Reset, cfg.BlockReward, *epochLength)
+	totals := make(map[string]float64)
+	for start := 0; start < len(blocks); start += *epochLength {
+		end := min(start+*epochLength, len(blocks))
+		epoch := make(map[string]float64)
+		for i := start; i < end; i++ {
+			for account, reward := range blockRewards(validatorSetAt(cfg, blocks, i), blocks[i], cfg.BlockReward) {
+				epoch[account] += reward
+				totals[account] += reward
+			}
+		}
+		fmt.Printf("%sEpoch %d (blok %d-%d)%s\n", BoldCyan, start / *epochLength, start, end-1, Reset)
+		printRewards(epoch)
+	}
+	fmt.Printf("%sTotal%s\n", BoldCyan, Reset)
+	printRewards(totals)
+	return nil
+}
+
+// printRewards prints account earnings sorted by account name
+func printRewards(rewards map[string]float64) {
+	accounts := make([]string, 0, len(rewards))
+	for account := range rewards {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+	if len(accounts) == 0 {
+		fmt.Println("  (tidak ada blok yang difinalisasi)")
+	}
+	for _, account := range accounts {
+		fmt.Printf("  %-12s %10.2f\n", account, rewards[account])
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// stakingConfig is a hybrid chain with two validators and epochs of two blocks
+func stakingConfig() GenesisConfig {
+	cfg := defaultGenesisConfig
+	cfg.Consensus = consensusHybrid
+	cfg.EpochLength = 2
+	cfg.Validators = []Validator{
+		{Name: "v1", Stake: 100, Commission: 0.1},
+		{Name: "v2", Stake: 100, Delegations: []Delegation{{Delegator: "bob", Amount: 50}}},
+	}
+	return cfg
+}
+
+func TestChainDelegations(t *testing.T) {
+	cfg := stakingConfig()
+	blocks := []Block{
+		{Index: 0, Data: "Genesis Block"},
+		{Index: 1, Data: delegationData("alice", "v1", 100)},
+		{Index: 2, Data: "alice kirim bob 5"},
+		{Index: 3, Data: delegationData("carol", "v2", 10)},
+	}
+
+	// Delegasi di blok 1 berlaku mulai epoch 1 (blok 2), delegasi di blok 3 mulai epoch 2
+	for i, want := range []struct{ v1, v2 uint64 }{{100, 150}, {100, 150}, {200, 150}, {200, 150}} {
+		set := validatorSetAt(cfg, blocks, i)
+		if set[0].power() != want.v1 || set[1].power() != want.v2 {
+			t.Errorf("block %d: power v1 %d, v2 %d; want %d, %d", i, set[0].power(), set[1].power(), want.v1, want.v2)
+		}
+	}
+	if next := activeValidators(cfg.Validators, blocks); next[1].power() != 160 {
+		t.Errorf("power of v2 after block 3 = %d, want 160", next[1].power())
+	}
+	if len(cfg.Validators[1].Delegations) != 1 {
+		t.Errorf("activeValidators changed the genesis validator set")
+	}
+
+	// Blok 2 ditandatangani keduanya: v1 memegang 200 dari 350 power
+	blocks[2].Signatures = []ValidatorSignature{{Validator: "v1"}, {Validator: "v2"}}
+	rewards := blockRewards(validatorSetAt(cfg, blocks, 2), blocks[2], 35)
+	for account, want := range map[string]float64{"v1": 2 + 18*0.5, "alice": 18 * 0.5, "v2": 15 * 100.0 / 150, "bob": 15 * 50.0 / 150} {
+		if math.Abs(rewards[account]-want) > 1e-9 {
+			t.Errorf("reward of %s = %v, want %v", account, rewards[account], want)
+		}
+	}
+	if _, ok := rewards["carol"]; ok {
+		t.Errorf("carol earned in the epoch before her delegation takes effect")
+	}
+}
+
+func TestCheckDelegation(t *testing.T) {
+	slashing := Block{Index: 1, Data: "x", Evidence: []DoubleSignEvidence{{Validator: "v2"}}}
+
+	tests := []struct {
+		name      string
+		consensus string
+		prior     []Block
+		data      string
+		wantErr   string // Kosong berarti blok harus diterima
+	}{
+		{name: "delegation", data: delegationData("alice", "v1", 5)},
+		{name: "ordinary data", data: "delegasi ke v1"},
+		{name: "unknown validator", data: delegationData("alice", "v9", 5), wantErr: `unknown validator "v9"`},
+		{name: "zero amount", data: "delegate:alice:v1:0", wantErr: "positive integer"},
+		{name: "negative amount", data: "delegate:alice:v1:-5", wantErr: "positive integer"},
+		{name: "missing delegator", data: "delegate::v1:5", wantErr: "must be delegate:"},
+		{name: "extra field", data: "delegate:alice:v1:5:x", wantErr: "must be delegate:"},
+		{name: "slashed validator", prior: []Block{slashing}, data: delegationData("alice", "v2", 5), wantErr: `slashed validator "v2"`},
+		{name: "pow chain ignores delegation data", consensus: consensusPoW, data: delegationData("alice", "v9", 5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := stakingConfig()
+			if tt.consensus != "" {
+				cfg.Consensus = tt.consensus
+			}
+			blocks := append([]Block{{Index: 0}}, tt.prior...)
+			blocks = append(blocks, Block{Index: len(blocks), Data: tt.data})
+			err := checkDelegation(cfg, blocks, len(blocks)-1)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkDelegation: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkDelegation error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
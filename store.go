@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// BlockStore persists the blocks of a chain and the small records kept next to them
+// (genesis.json, node.key, mining-state.json)
+type BlockStore interface {
+	LoadBlocks() ([]Block, error)
+	SaveBlock(block Block) error
+	// ReadFile returns an error satisfying os.IsNotExist when the record does not exist
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm os.FileMode) error
+	Remove(name string) error
+}
+
+// fileBlockStore keeps every block as a JSON file in dir
+type fileBlockStore struct {
+	dir    string
+	cipher *storageCipher // Enkripsi blok di disk, nil berarti blok disimpan sebagai teks biasa
+	chaos  *chaosInjector // Fault penyimpanan yang disuntikkan chaos mode, nil berarti nonaktif
+}
+
+// encodeBlockFile returns the content of a block file before encryption: indented JSON and a newline
+func encodeBlockFile(block Block) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(block); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SaveBlock writes block to block<index>.json, encrypted when storage encryption is active
+func (s fileBlockStore) SaveBlock(block Block) error {
+	if err := os.MkdirAll(s.dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	data, err := encodeBlockFile(block)
+	if err != nil {
+		return err
+	}
+
+	// Enkripsi blok jika storage encryption aktif
+	filename := fmt.Sprintf("block%d.json", block.Index)
+	if data, err = sealRecord(s.cipher, filename, data); err != nil {
+		return err
+	}
+
+	// Chaos mode: gagalkan penulisan atau tulis file terpotong
+	fault, injected := s.chaos.pick(faultFailedWrite, faultTruncatedFile)
+	if injected && fault == faultFailedWrite {
+		return errChaosFault
+	}
+	if injected && fault == faultTruncatedFile {
+		data = data[:len(data)/2]
+	}
+
+	// Tulis ke file sementara lalu rename agar file blok tidak pernah setengah jadi
+	filePath := filepath.Join(s.dir, filename)
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	if injected {
+		// Simulasi penulisan yang terpotong di tengah jalan: pembaca melihat file blok yang
+		// rusak, sehingga loader menandainya sebagai blok rusak dan heal dapat memperbaikinya
+		if err := os.Rename(tmpPath, filePath); err != nil {
+			return err
+		}
+		return errChaosFault
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
+// LoadBlocks reads every block<index>.json in index order
+func (s fileBlockStore) LoadBlocks() ([]Block, error) {
+	var blockchain []Block
+
+	// Pastikan direktori blok ada
+	if _, err := os.Stat(s.dir); os.IsNotExist(err) {
+		return blockchain, nil // Tidak ada blok yang disimpan
+	}
+
+	files, err := filepath.Glob(filepath.Join(s.dir, "block*.json"))
+	if err != nil {
+		return blockchain, err
+	}
+
+	// Sort files berdasarkan index
+	sort.Slice(files, func(i, j int) bool {
+		var indexI, indexJ int
+		fmt.Sscanf(filepath.Base(files[i]), "block%d.json", &indexI)
+		fmt.Sscanf(filepath.Base(files[j]), "block%d.json", &indexJ)
+		return indexI < indexJ
+	})
+
+	for _, file := range files {
+		var index int
+		fmt.Sscanf(filepath.Base(file), "block%d.json", &index)
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return blockchain, err
+		}
+
+		// Gagal dekripsi bisa berarti passphrase salah, jadi tidak dianggap blok rusak
+		data, err = openRecord(s.cipher, filepath.Base(file), data)
+		if err != nil {
+			return blockchain, fmt.Errorf("%s: %w", file, err)
+		}
+
+		block, err := decodeBlock(data)
+		if err != nil {
+			return blockchain, &corruptBlockError{File: file, Index: index, Err: err}
+		}
+		blockchain = append(blockchain, block)
+	}
+
+	return blockchain, nil
+}
+
+// ReadFile reads a record from dir
+func (s fileBlockStore) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(s.dir, name))
+}
+
+// WriteFile writes a record to dir through a temporary file so it is never half written
+func (s fileBlockStore) WriteFile(name string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(s.dir, os.ModePerm); err != nil {
+		return err
+	}
+	path := filepath.Join(s.dir, name)
+	if err := os.WriteFile(path+".tmp", data, perm); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// Remove deletes a record from dir
+func (s fileBlockStore) Remove(name string) error {
+	return os.Remove(filepath.Join(s.dir, name))
+}
+
+// errReadOnlyStore is returned by every write to a store mounted read-only
+var errReadOnlyStore = errors.New("penyimpanan blockchain dibuka read-only")
+
+// readOnlyBlockStore wraps a store and rejects every write, so a chain directory of someone
+// else's live node or a mounted archive can be analysed and served safely
+type readOnlyBlockStore struct {
+	BlockStore
+}
+
+func (readOnlyBlockStore) SaveBlock(Block) error                       { return errReadOnlyStore }
+func (readOnlyBlockStore) WriteFile(string, []byte, os.FileMode) error { return errReadOnlyStore }
+func (readOnlyBlockStore) Remove(string) error                         { return errReadOnlyStore }
+
+// onDiskStore returns the file store behind store, also when it is mounted read-only
+func onDiskStore(store BlockStore) (fileBlockStore, bool) {
+	if readOnly, ok := store.(readOnlyBlockStore); ok {
+		store = readOnly.BlockStore
+	}
+	files, ok := store.(fileBlockStore)
+	return files, ok
+}
+
+// memoryBlockStore keeps everything in memory, for demos and experiments that should not
+// touch the disk and always start from a new genesis block
+type memoryBlockStore struct {
+	mu     sync.Mutex
+	blocks map[int]Block
+	files  map[string][]byte
+}
+
+func newMemoryBlockStore() *memoryBlockStore {
+	return &memoryBlockStore{blocks: make(map[int]Block), files: make(map[string][]byte)}
+}
+
+// LoadBlocks returns the stored blocks in index order
+func (s *memoryBlockStore) LoadBlocks() ([]Block, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	blockchain := make([]Block, 0, len(s.blocks))
+	for _, block := range s.blocks {
+		blockchain = append(blockchain, block)
+	}
+	sort.Slice(blockchain, func(i, j int) bool { return blockchain[i].Index < blockchain[j].Index })
+	return blockchain, nil
+}
+
+// SaveBlock stores block, replacing a stored block with the same index like the file store does
+func (s *memoryBlockStore) SaveBlock(block Block) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blocks[block.Index] = block
+	return nil
+}
+
+// ReadFile returns a copy of a stored record
+func (s *memoryBlockStore) ReadFile(name string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// WriteFile stores a copy of data; perm is ignored
+func (s *memoryBlockStore) WriteFile(name string, data []byte, perm os.FileMode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[name] = append([]byte(nil), data...)
+	return nil
+}
+
+// Remove deletes a stored record
+func (s *memoryBlockStore) Remove(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.files[name]; !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	delete(s.files, name)
+	return nil
+}
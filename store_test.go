@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// testStore returns an unencrypted store in a temporary directory holding blockchain
+func testStore(t *testing.T, blockchain []Block) fileBlockStore {
+	t.Helper()
+	store := fileBlockStore{dir: t.TempDir()}
+	for _, block := range blockchain {
+		if err := store.SaveBlock(block); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return store
+}
+
+// blockHashes returns the hashes of the blocks in store
+func blockHashes(t *testing.T, store BlockStore) []string {
+	t.Helper()
+	blocks, err := store.LoadBlocks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hashes []string
+	for _, block := range blocks {
+		hashes = append(hashes, block.Hash)
+	}
+	return hashes
+}
+
+// TestBlockStores runs the same sequence against the disk and the in-memory store, which
+// must behave alike so experiments and demos see what a node on disk would
+func TestBlockStores(t *testing.T) {
+	cfg := fixtureGenesisConfig()
+	blockchain := fixtureChain(cfg, 1, 4, 1)
+
+	stores := map[string]func() BlockStore{
+		"file":   func() BlockStore { return fileBlockStore{dir: t.TempDir()} },
+		"memory": func() BlockStore { return newMemoryBlockStore() },
+	}
+	for name, open := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := open()
+			if blocks, err := store.LoadBlocks(); err != nil || len(blocks) != 0 {
+				t.Fatalf("new store has %d blocks, err %v", len(blocks), err)
+			}
+
+			// Urutan penyimpanan tidak menentukan urutan saat dimuat
+			for _, i := range []int{2, 0, 3, 1} {
+				if err := store.SaveBlock(blockchain[i]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got, want := blockHashes(t, store), blockHashes(t, testStore(t, blockchain)); !reflect.DeepEqual(got, want) {
+				t.Errorf("LoadBlocks = %v, want %v", got, want)
+			}
+
+			// Blok dengan index yang sama menggantikan blok lama
+			replaced := blockchain[3]
+			replaced.Data = "diganti"
+			if err := store.SaveBlock(replaced); err != nil {
+				t.Fatal(err)
+			}
+			blocks, err := store.LoadBlocks()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(blocks) != 4 || blocks[3].Data != "diganti" {
+				t.Errorf("after replacing block 3: %d blocks, block 3 data %q", len(blocks), blocks[3].Data)
+			}
+
+			if _, err := store.ReadFile(nodeKeyFile); !os.IsNotExist(err) {
+				t.Errorf("ReadFile of a missing record: %v, want not exist", err)
+			}
+			record := []byte(`{"a": 1}`)
+			if err := store.WriteFile(nodeKeyFile, record, 0600); err != nil {
+				t.Fatal(err)
+			}
+			record[0] = 'x' // Store tidak boleh berbagi slice dengan pemanggil
+			got, err := store.ReadFile(nodeKeyFile)
+			if err != nil || !bytes.Equal(got, []byte(`{"a": 1}`)) {
+				t.Errorf("ReadFile = %q, %v", got, err)
+			}
+			got[0] = 'x'
+			if again, _ := store.ReadFile(nodeKeyFile); !bytes.Equal(again, []byte(`{"a": 1}`)) {
+				t.Errorf("ReadFile returned the stored slice itself")
+			}
+
+			if err := store.Remove(nodeKeyFile); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.Remove(nodeKeyFile); !os.IsNotExist(err) {
+				t.Errorf("Remove of a missing record: %v, want not exist", err)
+			}
+		})
+	}
+}
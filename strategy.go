@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MinerStrategy decides what a miner mines and when it is published.
+// Implementations can be registered in minerStrategies to experiment with
+// alternative behaviour (empty blocks, block withholding) without touching the core.
+type MinerStrategy interface {
+	// Name returns the identifier used by the -strategy flag
+	Name() string
+	// BlockData returns the payload to mine given the data requested by the user
+	BlockData(requested string) string
+	// Parent selects the block the next block is built on
+	Parent(blockchain []Block) Block
+	// Publish is called with a freshly mined block and returns the blocks to publish now
+	Publish(mined Block) []Block
+}
+
+// honestStrategy mines the requested data on the tip and publishes immediately
+type honestStrategy struct{}
+
+func (honestStrategy) Name() string                      { return "honest" }
+func (honestStrategy) BlockData(requested string) string { return requested }
+func (honestStrategy) Parent(blockchain []Block) Block   { return blockchain[len(blockchain)-1] }
+func (honestStrategy) Publish(mined Block) []Block       { return []Block{mined} }
+
+// emptyBlockStrategy ignores the requested data and always mines empty blocks
+type emptyBlockStrategy struct {
+	honestStrategy
+}
+
+func (emptyBlockStrategy) Name() string            { return "empty" }
+func (emptyBlockStrategy) BlockData(string) string { return "" }
+
+// withholdingStrategy keeps its mined blocks private until it holds a given number of them
+type withholdingStrategy struct {
+	Threshold int
+	withheld  []Block
+}
+
+func (s *withholdingStrategy) Name() string                      { return "withhold" }
+func (s *withholdingStrategy) BlockData(requested string) string { return requested }
+
+// Parent builds on the private chain when one exists, otherwise on the public tip
+func (s *withholdingStrategy) Parent(blockchain []Block) Block {
+	if len(s.withheld) > 0 {
+		return s.withheld[len(s.withheld)-1]
+	}
+	return blockchain[len(blockchain)-1]
+}
+
+func (s *withholdingStrategy) Publish(mined Block) []Block {
+	s.withheld = append(s.withheld, mined)
+	if len(s.withheld) < s.Threshold {
+		return nil
+	}
+	published := s.withheld
+	s.withheld = nil
+	return published
+}
+
+// minerStrategies lists the strategies selectable with the -strategy flag
+var minerStrategies = map[string]func() MinerStrategy{
+	"honest":   func() MinerStrategy { return honestStrategy{} },
+	"empty":    func() MinerStrategy { return emptyBlockStrategy{} },
+	"withhold": func() MinerStrategy { return &withholdingStrategy{Threshold: 2} },
+}
+
+// newMinerStrategy returns the strategy registered under name
+func newMinerStrategy(name string) (MinerStrategy, error) {
+	constructor, ok := minerStrategies[name]
+	if !ok {
+		return nil, fmt.Errorf("strategi miner tidak dikenal: %q (tersedia: %v)", name, minerStrategyNames())
+	}
+	return constructor(), nil
+}
+
+// minerStrategyNames returns the registered strategy names in sorted order
+func minerStrategyNames() []string {
+	names := make([]string, 0, len(minerStrategies))
+	for name := range minerStrategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// submitBlock validates an externally produced block against the tip of chain and appends it
+func submitBlock(chain *Chain, block Block) error {
+	blocks := chain.Blocks()
+	tip := blocks[len(blocks)-1]
+	if block.PreviousHash != tip.Hash {
+		// Blok yang menyambung ke blok lama akan membuat fork, yang belum didukung penyimpanan
+		for _, ancestor := range blocks {
+			if ancestor.Hash == block.PreviousHash {
+				return fmt.Errorf("blok %d menyambung ke blok %d, bukan ke tip %d; fork belum didukung", block.Index, ancestor.Index, tip.Index)
+			}
+		}
+		return fmt.Errorf("parent blok %d (%s) tidak dikenal", block.Index, block.PreviousHash)
+	}
+	if block.Index != tip.Index+1 {
+		return fmt.Errorf("blok menyambung ke tip %d tetapi memiliki index %d", tip.Index, block.Index)
+	}
+	return chain.Append(block)
+}
+
+// runSubmitBlockCommand implements "submitblock <file|hex|->" for a chain that is not running
+func runSubmitBlockCommand(store BlockStore, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("penggunaan: submitblock <file|hex|->")
+	}
+
+	var data []byte
+	var err error
+	switch {
+	case args[0] == "-":
+		data, err = io.ReadAll(os.Stdin)
+	case fileExists(args[0]):
+		data, err = os.ReadFile(args[0])
+	default:
+		data = []byte(args[0])
+	}
+	if err != nil {
+		return err
+	}
+	block, err := readBlockBlob(data)
+	if err != nil {
+		return fmt.Errorf("input bukan blok yang valid: %w", err)
+	}
+
+	cfg, blocks, err := loadChain(store)
+	if err != nil {
+		return err
+	}
+	if len(blocks) == 0 {
+		return fmt.Errorf("blockchain lokal kosong")
+	}
+	if err := submitBlock(newChain(cfg, store, blocks, blocks[len(blocks)-1].Difficulty), block); err != nil {
+		return err
+	}
+	fmt.Printf(Green+"Blok %d (%s) diterima dan disimpan.\n"+Reset, block.Index, block.Hash)
+	return nil
+}
+
+// fileExists reports whether path names an existing file
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// handleSubmitBlock accepts a block mined elsewhere and appends it if it passes every rule
+func (s *apiServer) handleSubmitBlock(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	block, err := readBlockBlob(data)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "body bukan blok yang valid: "+err.Error())
+		return
+	}
+	if err := submitBlock(s.chain, block); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, block)
+}
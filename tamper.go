@@ -0,0 +1,186 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// tamperings are the ways the tamper command can corrupt a block, as used in class exercises
+var tamperings = map[string]struct {
+	description string
+	apply       func(block *Block) error
+}{
+	"data": {"Ubah isi Data tanpa mining ulang", func(block *Block) error {
+		block.Data += " (diubah)"
+		return nil
+	}},
+	"nonce": {"Ganti nonce", func(block *Block) error {
+		block.Nonce++
+		return nil
+	}},
+	"prev-hash": {"Arahkan PreviousHash ke hash yang salah", func(block *Block) error {
+		block.PreviousHash = genesisPreviousHash[:len(genesisPreviousHash)-1] + "1"
+		return nil
+	}},
+	"difficulty": {"Klaim tingkat kesulitan yang lebih tinggi dari hash sebenarnya", func(block *Block) error {
+		if block.Difficulty >= 64 {
+			return fmt.Errorf("difficulty blok sudah maksimum")
+		}
+		block.Difficulty++
+		return nil
+	}},
+	"timestamp": {"Majukan timestamp jauh ke masa depan", func(block *Block) error {
+		block.Timestamp = time.Now().Add(24 * time.Hour).Format(time.RFC3339)
+		return nil
+	}},
+	"signature": {"Rusak tanda tangan validator pertama", func(block *Block) error {
+		if len(block.Signatures) == 0 {
+			return fmt.Errorf("blok %d tidak memiliki tanda tangan validator", block.Index)
+		}
+		signature, err := hex.DecodeString(block.Signatures[0].Signature)
+		if err != nil || len(signature) == 0 {
+			return fmt.Errorf("tanda tangan blok %d tidak dapat dibaca", block.Index)
+		}
+		signature[0] ^= 0xff
+		block.Signatures = append([]ValidatorSignature(nil), block.Signatures...)
+		block.Signatures[0].Signature = hex.EncodeToString(signature)
+		return nil
+	}},
+	"evidence": {"Hapus evidence slashing agar validator lolos dari slashing", func(block *Block) error {
+		if len(block.Evidence) == 0 {
+			return fmt.Errorf("blok %d tidak memuat evidence slashing", block.Index)
+		}
+		block.Evidence = nil
+		return nil
+	}},
+}
+
+// tamperingNames returns the tampering kinds in sorted order
+func tamperingNames() []string {
+	names := make([]string, 0, len(tamperings))
+	for name := range tamperings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// writeSandbox writes cfg as genesis.json and every block, sealed with the cipher of files, to
+// dir/blocks so the tampered chain can be inspected or loaded without touching the original
+func writeSandbox(dir string, cfg GenesisConfig, files fileBlockStore, blockchain []Block) error {
+	blocksDir := filepath.Join(dir, "blocks")
+	if err := os.MkdirAll(blocksDir, os.ModePerm); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(blocksDir, genesisConfigFile), append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	// Salt ikut disalin agar salinan dapat dibuka dengan passphrase yang sama
+	if files.cipher != nil {
+		params, err := files.ReadFile(storageKeyFile)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err == nil {
+			if err := os.WriteFile(filepath.Join(blocksDir, storageKeyFile), params, 0644); err != nil {
+				return err
+			}
+		}
+	}
+	for _, block := range blockchain {
+		data, err := encodeBlockFile(block)
+		if err != nil {
+			return err
+		}
+		filename := fmt.Sprintf("block%d.json", block.Index)
+		if data, err = sealRecord(files.cipher, filename, data); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(blocksDir, filename), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runTamperCommand implements "tamper [-out dir] <index> <jenis>"
+func runTamperCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("tamper", flag.ContinueOnError)
+	out := fs.String("out", "", "Direktori untuk menyimpan salinan chain yang dirusak (kosong berarti hanya di memori)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fmt.Println(BoldYellow + "Jenis perusakan:" + Reset)
+		for _, name := range tamperingNames() {
+			fmt.Printf("  %s%-12s%s %s\n", BoldCyan, name, Reset, tamperings[name].description)
+		}
+		return fmt.Errorf("penggunaan: tamper [-out dir] <index> <jenis>")
+	}
+	var index int
+	if _, err := fmt.Sscan(fs.Arg(0), &index); err != nil {
+		return fmt.Errorf("index harus berupa angka")
+	}
+	tampering, ok := tamperings[fs.Arg(1)]
+	if !ok {
+		return fmt.Errorf("jenis perusakan tidak dikenal: %q (tersedia: %v)", fs.Arg(1), tamperingNames())
+	}
+
+	cfg, original, err := loadChain(store)
+	if err != nil {
+		return err
+	}
+	if index < 0 || index >= len(original) {
+		return fmt.Errorf("blok %d tidak ditemukan", index)
+	}
+
+	// Chain asli tidak disentuh; semua perubahan terjadi pada salinan
+	sandbox := append([]Block(nil), original...)
+	if err := tampering.apply(&sandbox[index]); err != nil {
+		return err
+	}
+	fmt.Printf(BoldYellow+"\nBlok %d dirusak: %s\n"+Reset, index, tampering.description)
+
+	// Setiap aturan dijalankan terpisah agar terlihat semua aturan yang menangkap perusakan
+	fmt.Println(BoldYellow + "\n=== Aturan yang menangkap perusakan ===" + Reset)
+	caught := false
+	for _, rule := range validationRules {
+		if err := rule.Check(cfg, sandbox, index); err != nil {
+			caught = true
+			fmt.Printf(Red+"%-12s DITANGKAP: %v\n"+Reset, rule.Name, err)
+		} else {
+			fmt.Printf(Green+"%-12s lolos\n"+Reset, rule.Name)
+		}
+	}
+	if index+1 < len(sandbox) {
+		if rule, err := validateBlock(cfg, sandbox, index+1); err != nil {
+			fmt.Printf(Red+"Blok %d setelahnya juga gagal: [%s] %v\n"+Reset, index+1, rule, err)
+		}
+	}
+
+	fmt.Println(BoldYellow + "\n=== Validasi seluruh chain ===" + Reset)
+	isBlockchainValid(cfg, sandbox)
+	if !caught {
+		fmt.Println(Yellow + "Tidak ada aturan yang menangkap perubahan ini pada blok tersebut." + Reset)
+	}
+
+	if *out != "" {
+		// Salinan dienkripsi dengan key yang sama seperti chain asli
+		files, _ := onDiskStore(store)
+		if err := writeSandbox(*out, cfg, files, sandbox); err != nil {
+			return err
+		}
+		fmt.Printf(Green+"Salinan chain yang dirusak disimpan di %s (jalankan program di direktori itu untuk memeriksanya).\n"+Reset, *out)
+	}
+	return nil
+}
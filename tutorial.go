@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// leadingZeros counts the leading zero hex digits of hash
+func leadingZeros(hash string) int {
+	return len(hash) - len(strings.TrimLeft(hash, "0"))
+}
+
+// tutorialPause waits for Enter unless the tutorial runs non-interactively
+func tutorialPause(reader *bufio.Reader, interactive bool) {
+	if !interactive {
+		return
+	}
+	fmt.Print(Cyan + "\n[Enter untuk lanjut]" + Reset)
+	reader.ReadString('\n')
+}
+
+// runTutorialCommand implements "tutorial [-difficulty D] [-show N] [-delay d] [-yes] [data]": it
+// mines one practice block on the local tip step by step, without saving it
+func runTutorialCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("tutorial", flag.ContinueOnError)
+	difficulty := fs.Int("difficulty", 2, "Tingkat kesulitan blok latihan")
+	show := fs.Int("show", 8, "Jumlah percobaan nonce pertama yang ditampilkan satu per satu")
+	delay := fs.Duration("delay", 400*time.Millisecond, "Jeda antar percobaan yang ditampilkan")
+	yes := fs.Bool("yes", false, "Jangan menunggu Enter di antara langkah")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *difficulty < 1 || *difficulty > 5 {
+		return fmt.Errorf("difficulty tutorial harus antara 1 dan 5")
+	}
+	data := "Blok latihan pertamaku"
+	if fs.NArg() > 0 {
+		data = strings.Join(fs.Args(), " ")
+	}
+
+	cfg, blockchain, err := loadChain(store)
+	if err != nil {
+		return err
+	}
+	parent := Block{Index: -1, Hash: genesisPreviousHash}
+	if len(blockchain) > 0 {
+		parent = blockchain[len(blockchain)-1]
+	}
+	reader := bufio.NewReader(os.Stdin)
+	interactive := !*yes
+
+	fmt.Println(BoldYellow + "\n=== Tutorial Mining ===" + Reset)
+	fmt.Println("Mining berarti mencari nonce sehingga hash blok diawali sejumlah nol.")
+	fmt.Printf("Blok latihan ini menyambung ke blok %d dan tidak disimpan ke blockchain.\n", parent.Index)
+
+	// Langkah 1: isi blok dan byte yang di-hash
+	block := Block{Index: parent.Index + 1, Timestamp: time.Now().Format(time.RFC3339), Data: data, PreviousHash: parent.Hash, Difficulty: *difficulty}
+	fmt.Println(BoldYellow + "\nLangkah 1: header blok" + Reset)
+	fmt.Println("Hash dihitung dari field berikut yang disambung menjadi satu string byte:")
+	for _, field := range blockRecordFields(block) {
+		note := ""
+		if field.Name == "nonce" {
+			note = Magenta + "  <- satu-satunya yang diubah miner" + Reset
+		}
+		fmt.Printf("  %s%-14s%s %q%s\n", BoldCyan, field.Name, Reset, field.Value, note)
+	}
+	tutorialPause(reader, interactive)
+
+	// Langkah 2: target
+	target := strings.Repeat("0", *difficulty)
+	fmt.Println(BoldYellow + "\nLangkah 2: target" + Reset)
+	fmt.Printf("Difficulty %d: hash harus diawali %s%s%s.\n", *difficulty, Green, target, Reset)
+	fmt.Printf("Setiap digit hex bernilai 0 dengan peluang 1/16, jadi rata-rata perlu sekitar %.0f percobaan.\n", expectedHashes(*difficulty))
+	tutorialPause(reader, interactive)
+
+	// Langkah 3: beberapa percobaan pertama, satu per satu
+	fmt.Println(BoldYellow + "\nLangkah 3: mencoba nonce satu per satu" + Reset)
+	start := time.Now()
+	for block.Nonce = 0; ; block.Nonce++ {
+		block.Hash = calculateHash(cfg, block)
+		zeros := leadingZeros(block.Hash)
+		found := zeros >= *difficulty
+
+		if block.Nonce < uint64(*show) || found {
+			// Bagian awal hash yang dibandingkan dengan target diberi warna
+			head, tail := block.Hash[:*difficulty], block.Hash[*difficulty:]
+			color := Red
+			verdict := fmt.Sprintf("gagal: hanya %d nol di awal, perlu %d", zeros, *difficulty)
+			if found {
+				color = BoldGreen
+				verdict = "BERHASIL"
+			}
+			fmt.Printf("nonce %-8d %s%s%s%s  %s\n", block.Nonce, color, head, Reset, tail, verdict)
+			if !found {
+				time.Sleep(*delay)
+			}
+		}
+		if block.Nonce == uint64(*show) && !found {
+			fmt.Println(Yellow + "... mempercepat: percobaan berikutnya tidak ditampilkan" + Reset)
+		}
+		if found {
+			break
+		}
+	}
+
+	// Langkah 4: hasil
+	fmt.Println(BoldYellow + "\nLangkah 4: blok ditemukan" + Reset)
+	fmt.Printf("Nonce %d ditemukan setelah %d percobaan (%v).\n", block.Nonce, block.Nonce+1, time.Since(start).Round(time.Millisecond))
+	fmt.Println("Siapa pun dapat memeriksanya dengan satu kali hash: ubah satu huruf Data dan hash akan berubah total.")
+	changed := block
+	changed.Data += "!"
+	fmt.Printf("  %-16s %s\n  %-16s %s\n", "hash asli", block.Hash, "Data + \"!\"", calculateHash(cfg, changed))
+	fmt.Println(Yellow + "\nBlok latihan tidak disimpan. Gunakan menu 1 untuk mining blok sungguhan." + Reset)
+	return nil
+}
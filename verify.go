@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// parseVerifyLevel converts a level name to a verifyLevel
+func parseVerifyLevel(name string) (verifyLevel, error) {
+	level, ok := verifyLevelNames[name]
+	if !ok {
+		return 0, fmt.Errorf("level verifikasi tidak dikenal: %q (gunakan quick, standard, atau paranoid)", name)
+	}
+	return level, nil
+}
+
+// verifyChain checks every block at the given level and returns the duration and the first failure
+func verifyChain(cfg GenesisConfig, blockchain []Block, level verifyLevel) (time.Duration, string, error) {
+	defer startSpan("validate.chain")()
+	start := time.Now()
+	for i := range blockchain {
+		if rule, err := validateBlockAt(cfg, blockchain, i, level); err != nil {
+			return time.Since(start), rule, err
+		}
+	}
+	return time.Since(start), "", nil
+}
+
+// runValidateCommand implements "validate [-level quick|standard|paranoid|all]"
+func runValidateCommand(store BlockStore, args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	levelName := fs.String("level", "paranoid", "Level verifikasi: quick, standard, paranoid, atau all untuk membandingkan waktunya")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var levels []verifyLevel
+	if *levelName == "all" {
+		for _, level := range verifyLevelNames {
+			levels = append(levels, level)
+		}
+		sort.Slice(levels, func(i, j int) bool { return levels[i] < levels[j] })
+	} else {
+		level, err := parseVerifyLevel(*levelName)
+		if err != nil {
+			return err
+		}
+		levels = []verifyLevel{level}
+	}
+
+	cfg, blockchain, err := loadChain(store)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(BoldYellow+"\n=== Verifikasi %d blok ===\n"+Reset, len(blockchain))
+	failed := false
+	for _, level := range levels {
+		elapsed, rule, err := verifyChain(cfg, blockchain, level)
+		if err != nil {
+			failed = true
+			fmt.Printf(Red+"%-9s gagal dalam %v: [%s] %v\n"+Reset, level, elapsed, rule, err)
+			continue
+		}
+		fmt.Printf(Green+"%-9s valid dalam %v\n"+Reset, level, elapsed)
+	}
+	if failed {
+		return fmt.Errorf("blockchain tidak valid")
+	}
+	return nil
+}
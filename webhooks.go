@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// Pengiriman webhook dicoba ulang dengan backoff eksponensial
+const (
+	webhookAttempts = 5
+	webhookBackoff  = time.Second
+	webhookTimeout  = 10 * time.Second
+)
+
+// webhookQueueSize is how many events wait for one webhook; further events are dropped while
+// its receiver is down, so a dead URL costs one goroutine and a fixed amount of memory
+const webhookQueueSize = 64
+
+// webhook is one registered URL and the event types it receives (empty means all)
+type webhook struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events,omitempty"`
+}
+
+// wants reports whether the webhook subscribed to the event type
+func (h webhook) wants(eventType string) bool {
+	if len(h.Events) == 0 {
+		return true
+	}
+	for _, name := range h.Events {
+		if name == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the URL and the event names
+func (h webhook) validate() error {
+	target, err := url.Parse(h.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("url webhook harus berupa URL http atau https: %q", h.URL)
+	}
+	for _, name := range h.Events {
+		switch name {
+		case eventBlock, eventJob, eventValidationFailure:
+		default:
+			return fmt.Errorf("event webhook tidak dikenal: %q (gunakan %s, %s, atau %s)", name, eventBlock, eventJob, eventValidationFailure)
+		}
+	}
+	return nil
+}
+
+// webhookDelivery is one event waiting in a webhook queue
+type webhookDelivery struct {
+	eventType string
+	payload   []byte
+}
+
+// webhookDispatcher delivers chain events to registered webhooks, one worker and one bounded
+// queue per webhook
+type webhookDispatcher struct {
+	mu     sync.Mutex
+	hooks  []webhook
+	queues []chan webhookDelivery // Sejajar dengan hooks; dibuat saat event pertama
+}
+
+// loadWebhooks reads a JSON array of {"url", "events"} objects
+func loadWebhooks(path string) ([]webhook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var hooks []webhook
+	if err := json.Unmarshal(data, &hooks); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, hook := range hooks {
+		if err := hook.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return hooks, nil
+}
+
+// Register adds a webhook at runtime
+func (d *webhookDispatcher) Register(hook webhook) error {
+	if err := hook.validate(); err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.hooks = append(d.hooks, hook)
+	return nil
+}
+
+// Hooks returns a copy of the registered webhooks
+func (d *webhookDispatcher) Hooks() []webhook {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]webhook(nil), d.hooks...)
+}
+
+// run delivers every event published on bus until ctx is cancelled
+func (d *webhookDispatcher) run(ctx context.Context, bus *eventBus) {
+	events, unsubscribe := bus.Subscribe()
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+	}()
+	for event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			continue
+		}
+		d.enqueue(ctx, event.Type, payload)
+	}
+}
+
+// enqueue queues the event for every webhook that wants it, starting a webhook's worker on
+// its first event; a full queue drops the event instead of blocking the other webhooks
+func (d *webhookDispatcher) enqueue(ctx context.Context, eventType string, payload []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, hook := range d.hooks {
+		if !hook.wants(eventType) {
+			continue
+		}
+		for len(d.queues) <= i {
+			d.queues = append(d.queues, nil)
+		}
+		if d.queues[i] == nil {
+			d.queues[i] = make(chan webhookDelivery, webhookQueueSize)
+			go deliverWebhooks(ctx, hook.URL, d.queues[i])
+		}
+		select {
+		case d.queues[i] <- webhookDelivery{eventType: eventType, payload: payload}:
+		default:
+			fmt.Printf(Yellow+"Antrian webhook %s penuh; event %s dibuang\n"+Reset, hook.URL, eventType)
+		}
+	}
+}
+
+// deliverWebhooks sends the queued events to target one at a time until ctx is cancelled
+func deliverWebhooks(ctx context.Context, target string, queue <-chan webhookDelivery) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case delivery := <-queue:
+			deliverWebhook(ctx, target, delivery.eventType, delivery.payload)
+		}
+	}
+}
+
+// deliverWebhook POSTs payload to target, retrying with exponential backoff on errors and
+// non-2xx responses
+func deliverWebhook(ctx context.Context, target, eventType string, payload []byte) error {
+	client := &http.Client{Timeout: webhookTimeout}
+	backoff := webhookBackoff
+	var lastErr error
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Webhook-Event", eventType)
+		req.Header.Set("X-Webhook-Attempt", fmt.Sprint(attempt))
+
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < 300 {
+				return nil
+			}
+			err = fmt.Errorf("HTTP %d", resp.StatusCode)
+		}
+		lastErr = err
+		if attempt < webhookAttempts {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+	}
+	fmt.Printf(Yellow+"Webhook %s gagal setelah %d percobaan: %v\n"+Reset, target, webhookAttempts, lastErr)
+	return lastErr
+}
+
+func (s *apiServer) handleListWebhooks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.webhooks.Hooks())
+}
+
+// handleRegisterWebhook registers {"url": "...", "events": [...]} until the node restarts
+func (s *apiServer) handleRegisterWebhook(w http.ResponseWriter, r *http.Request) {
+	var hook webhook
+	if err := json.NewDecoder(r.Body).Decode(&hook); err != nil {
+		writeError(w, http.StatusBadRequest, "body harus berupa JSON {\"url\": \"...\", \"events\": [...]}")
+		return
+	}
+	if err := s.webhooks.Register(hook); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, hook)
+}
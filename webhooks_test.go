@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestWebhookQueue checks that a stalled receiver gets one request at a time and that events
+// beyond its queue are dropped instead of piling up
+func TestWebhookQueue(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var active, maxActive, received int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		active++
+		maxActive = max(maxActive, active)
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		active--
+		received++
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := &webhookDispatcher{hooks: []webhook{{URL: server.URL, Events: []string{eventBlock}}}}
+	const events = 3 * webhookQueueSize
+	for i := 0; i < events; i++ {
+		d.enqueue(ctx, eventBlock, []byte("{}"))
+		d.enqueue(ctx, eventJob, []byte("{}")) // Tidak diminta webhook ini
+	}
+	close(release)
+
+	// Satu event sedang dikirim saat antrian terisi, sisanya dibuang
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		done := received
+		mu.Unlock()
+		if done >= webhookQueueSize || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if maxActive != 1 {
+		t.Errorf("receiver saw %d concurrent deliveries, want 1", maxActive)
+	}
+	if received < webhookQueueSize || received > webhookQueueSize+1 {
+		t.Errorf("receiver got %d of %d events, want the %d queued ones", received, events, webhookQueueSize)
+	}
+}